@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    lambdalabs = {
+      source = "elct9620/lambdalabs"
+    }
+  }
+}
+
+provider "lambdalabs" {}
+
+# A new key pair is generated on every run and it is never registered with Lambdalabs
+ephemeral "lambdalabs_ssh_key" "temporary" {
+  algorithm = "ed25519"
+}
+
+# The private key can be passed to write-only arguments, provider configurations or provisioner connections
+locals {
+  private_key = ephemeral.lambdalabs_ssh_key.temporary.private_key
+}
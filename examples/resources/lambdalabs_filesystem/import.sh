@@ -0,0 +1,5 @@
+# Import by file system ID
+terraform import lambdalabs_filesystem.shared 398578a2336b49079e74043f0bd2cfe8
+
+# Import by name, the name must match exactly one file system
+terraform import lambdalabs_filesystem.shared name:shared
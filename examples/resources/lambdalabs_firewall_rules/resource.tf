@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    lambdalabs = {
+      source = "elct9620/lambdalabs"
+    }
+  }
+}
+
+provider "lambdalabs" {}
+
+resource "lambdalabs_firewall_rules" "default" {
+  rule {
+    protocol       = "tcp"
+    port_range     = [22, 22]
+    source_network = "0.0.0.0/0"
+    description    = "Allow SSH"
+  }
+
+  rule {
+    protocol       = "tcp"
+    port_range     = [8888, 8888]
+    source_network = "10.0.0.0/8"
+    description    = "Allow Jupyter from the VPN"
+  }
+
+  rule {
+    protocol       = "icmp"
+    source_network = "0.0.0.0/0"
+  }
+}
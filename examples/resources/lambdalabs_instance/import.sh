@@ -0,0 +1,8 @@
+# Import by instance ID
+terraform import lambdalabs_instance.stable_diffusion 0920582c7ff041399e34823a0be62549
+
+# Import by name, the name must match exactly one running instance
+terraform import lambdalabs_instance.stable_diffusion name:stable-diffusion
+
+# The user data cannot be read back, the user_data configured after import is trusted
+# and its hash is recorded by the next apply without replacing the instance
@@ -0,0 +1,27 @@
+terraform {
+  required_providers {
+    lambdalabs = {
+      source = "elct9620/lambdalabs"
+    }
+  }
+}
+
+provider "lambdalabs" {}
+
+resource "lambdalabs_ssh_key" "primary" {
+  name = "terraform"
+}
+
+resource "lambdalabs_instance_group" "training" {
+  name               = "training"
+  quantity           = 8
+  region_name        = "us-tx-1"
+  instance_type_name = "gpu_8x_h100_sxm5"
+  ssh_key_names = [
+    lambdalabs_ssh_key.primary.name
+  ]
+}
+
+output "private_ips" {
+  value = lambdalabs_instance_group.training.private_ips
+}
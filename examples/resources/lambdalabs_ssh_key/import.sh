@@ -0,0 +1,5 @@
+# Import by SSH key ID
+terraform import lambdalabs_ssh_key.primary 0920582c7ff041399e34823a0be62548
+
+# Import by name, the name must match exactly one SSH key
+terraform import lambdalabs_ssh_key.primary name:terraform
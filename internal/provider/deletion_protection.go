@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func deletionProtectionAttribute(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: "Prevent the " + kind + " from being destroyed or replaced, it must be set to `false` and applied before the " + kind + " can be deleted",
+		Optional:            true,
+	}
+}
+
+// checkDeletionProtection uses the value from the state, disabling the protection in the same plan as the destroy is not allowed
+func checkDeletionProtection(diags *diag.Diagnostics, protected types.Bool, kind, id string) {
+	if !protected.ValueBool() {
+		return
+	}
+
+	diags.AddError(
+		"Deletion protection enabled",
+		"The "+kind+" ID "+id+" has deletion_protection enabled, set deletion_protection = false and apply it before destroying or replacing the "+kind,
+	)
+}
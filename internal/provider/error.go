@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/elct9620/terraform-provider-lambdalabs/pkg/lambdalabs"
+)
+
+// errorHints explain how to resolve the errors which are caused by configuration or account state
+var errorHints = map[error]string{
+	lambdalabs.ErrUnauthorized:         "Check the provider api_key argument or the LAMBDALABS_API_KEY environment variable.",
+	lambdalabs.ErrAccountInactive:      "Verify the account email address and payment method in the Lambda Cloud dashboard.",
+	lambdalabs.ErrInsufficientCapacity: "The instance type has no available capacity in the region, try another region or instance type later.",
+	lambdalabs.ErrRateLimited:          "Lower the provider requests_per_second or max_concurrent_requests arguments, or reduce Terraform parallelism.",
+	lambdalabs.ErrQuotaExceeded:        "Terminate unused instances or ask Lambda support to raise the account quota.",
+	lambdalabs.ErrInUse:                "Detach or terminate the instances using it before retrying.",
+}
+
+func errorDetail(err error) string {
+	detail := []string{err.Error()}
+
+	var apiErr *lambdalabs.Error
+	if errors.As(err, &apiErr) && apiErr.Suggestion != "" {
+		detail = append(detail, apiErr.Suggestion)
+	}
+
+	for kind, hint := range errorHints {
+		if errors.Is(err, kind) {
+			detail = append(detail, hint)
+			break
+		}
+	}
+
+	return strings.Join(detail, "\n\n")
+}
@@ -129,7 +129,7 @@ func (d *filesystemData) Read(ctx context.Context, req datasource.ReadRequest, r
 
 	res, err := d.client.ListFileSystems(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to list file systems", err.Error())
+		resp.Diagnostics.AddError("failed to list file systems", errorDetail(err))
 		return
 	}
 
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -32,11 +33,13 @@ type filesystemDataModel struct {
 // filesystemResourceModel represents a filesystem model for resource operations
 // containing fields for creation, management and display
 type filesystemResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	Region     types.String `tfsdk:"region"`
-	MountPoint types.String `tfsdk:"mount_point"`
-	Created    types.String `tfsdk:"created"`
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Region             types.String   `tfsdk:"region"`
+	MountPoint         types.String   `tfsdk:"mount_point"`
+	Created            types.String   `tfsdk:"created"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 // filesystemsFilterModel represents filtering options for filesystems
@@ -2,18 +2,28 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/elct9620/terraform-provider-lambdalabs/pkg/lambdalabs"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	helper "github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
 var (
-	_ resource.Resource                = &filesystemResource{}
-	_ resource.ResourceWithConfigure   = &filesystemResource{}
-	_ resource.ResourceWithImportState = &filesystemResource{}
+	_                              resource.Resource                = &filesystemResource{}
+	_                              resource.ResourceWithConfigure   = &filesystemResource{}
+	_                              resource.ResourceWithImportState = &filesystemResource{}
+	_                              resource.ResourceWithModifyPlan  = &filesystemResource{}
+	defaultFilesystemDeleteTimeout                                  = 10 * time.Minute
 )
 
 type filesystemResource struct {
@@ -30,30 +40,51 @@ func (r *filesystemResource) Metadata(_ context.Context, req resource.MetadataRe
 }
 
 // Schema defines the schema for the resource.
-func (r *filesystemResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *filesystemResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manage File Systems",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: "File System ID",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "The File System name",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"region": schema.StringAttribute{
 				MarkdownDescription: "The region where the file system will be created",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"mount_point": schema.StringAttribute{
 				MarkdownDescription: "The mount point of the file system",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"created": schema.StringAttribute{
 				MarkdownDescription: "The creation timestamp of the file system",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
+			"deletion_protection": deletionProtectionAttribute("file system"),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -67,6 +98,34 @@ func (r *filesystemResource) Configure(_ context.Context, req resource.Configure
 	r.client = req.ProviderData.(*lambdalabs.Client)
 }
 
+// ModifyPlan rejects destroying or replacing a protected file system before apply.
+func (r *filesystemResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state filesystemResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.Plan.Raw.IsNull() {
+		var plan filesystemResourceModel
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Only a new name or region replaces the file system, the protection can be toggled in place
+		if plan.Name.Equal(state.Name) && plan.Region.Equal(state.Region) {
+			return
+		}
+	}
+
+	checkDeletionProtection(&resp.Diagnostics, state.DeletionProtection, "file system", state.ID.ValueString())
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *filesystemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var fs filesystemResourceModel
@@ -85,7 +144,7 @@ func (r *filesystemResource) Create(ctx context.Context, req resource.CreateRequ
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating File System",
-			"Could not create File System, unexpected error: "+err.Error(),
+			"Could not create File System, unexpected error: "+errorDetail(err),
 		)
 		return
 	}
@@ -113,29 +172,17 @@ func (r *filesystemResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	filesystems, err := r.client.ListFileSystems(ctx)
+	filesystem, err := r.findFileSystem(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Lambdalabs File System",
-			"Could not list Lambdalabs File Systems: "+err.Error(),
+			"Could not list Lambdalabs File Systems: "+errorDetail(err),
 		)
 		return
 	}
 
-	var filesystem *lambdalabs.FileSystem
-	filesystemId := state.ID.ValueString()
-	for _, fs := range filesystems.Data {
-		if fs.ID == filesystemId {
-			filesystem = &fs
-			break
-		}
-	}
-
 	if filesystem == nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Lambdalabs File System",
-			"Could not find Lambdalabs File System ID "+filesystemId,
-		)
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
@@ -155,15 +202,31 @@ func (r *filesystemResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *filesystemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddWarning(
-		"Update Lambdalabs File System",
-		"Unsupported Method",
-	)
+	var plan filesystemResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the provider side settings are updatable, the file system itself is replaced on changes
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-// ImportState imports the resource state from Terraform state.
+// ImportState imports the resource state by ID or name.
 func (r *filesystemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByName(ctx, req, resp, "file system", func(ctx context.Context) ([]importCandidate, error) {
+		res, err := r.client.ListFileSystems(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]importCandidate, 0, len(res.Data))
+		for _, fs := range res.Data {
+			candidates = append(candidates, importCandidate{ID: fs.ID, Name: fs.Name})
+		}
+
+		return candidates, nil
+	})
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -175,23 +238,102 @@ func (r *filesystemResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	res, err := r.client.DeleteFileSystem(ctx, &lambdalabs.DeleteFileSystemRequest{
-		ID: state.ID.ValueString(),
+	checkDeletionProtection(&resp.Diagnostics, state.DeletionProtection, "file system", state.ID.ValueString())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultFilesystemDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Instances destroyed in the same apply keep the file system in use until their termination completes
+	id := state.ID.ValueString()
+	err := helper.RetryContext(ctx, deleteTimeout, func() *helper.RetryError {
+		filesystem, err := r.findFileSystem(ctx, id)
+		if err != nil {
+			return helper.NonRetryableError(err)
+		}
+
+		if filesystem == nil {
+			return nil
+		}
+
+		if filesystem.IsInUse {
+			return helper.RetryableError(fmt.Errorf("file system %s is in use: %w", id, lambdalabs.ErrInUse))
+		}
+
+		res, err := r.client.DeleteFileSystem(ctx, &lambdalabs.DeleteFileSystemRequest{
+			ID: id,
+		})
+		if errors.Is(err, lambdalabs.ErrInUse) {
+			return helper.RetryableError(err)
+		}
+
+		if err != nil {
+			return helper.NonRetryableError(err)
+		}
+
+		// Check if the file system was actually deleted
+		if len(res.Data.DeletedIDs) == 0 {
+			return helper.NonRetryableError(errors.New("file system " + id + " was not deleted"))
+		}
+
+		return nil
 	})
-	if err != nil {
+
+	if errors.Is(err, lambdalabs.ErrInUse) {
 		resp.Diagnostics.AddError(
 			"Error Delete Lambdalabs File System",
-			"Could not delete Lambdalabs File System ID "+state.ID.ValueString()+": "+err.Error(),
+			"File System ID "+id+" is still in use "+r.describeBlockingInstances(ctx, state.Name.ValueString())+
+				" after waiting "+deleteTimeout.String()+", detach it or increase timeouts.delete",
 		)
 		return
 	}
 
-	// Check if the file system was actually deleted
-	if len(res.Data.DeletedIDs) == 0 {
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Delete Lambdalabs File System",
-			"File System ID "+state.ID.ValueString()+" was not deleted",
+			"Could not delete Lambdalabs File System ID "+id+": "+errorDetail(err),
 		)
 		return
 	}
 }
+
+func (r *filesystemResource) findFileSystem(ctx context.Context, id string) (*lambdalabs.FileSystem, error) {
+	filesystems, err := r.client.ListFileSystems(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fs := range filesystems.Data {
+		if fs.ID == id {
+			return &fs, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// describeBlockingInstances is best effort, the delete error is more relevant than failing to list instances
+func (r *filesystemResource) describeBlockingInstances(ctx context.Context, name string) string {
+	instances, err := r.client.ListInstances(ctx)
+	if err != nil {
+		return "by unknown instances"
+	}
+
+	var blocking []string
+	for _, instance := range instances.Data {
+		if slices.Contains(instance.FileSystemNames, name) {
+			blocking = append(blocking, instance.ID+" ("+instance.Status+")")
+		}
+	}
+
+	if len(blocking) == 0 {
+		return "by unknown instances"
+	}
+
+	return "by instances " + strings.Join(blocking, ", ")
+}
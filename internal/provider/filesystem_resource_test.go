@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func Test_FilesystemResource(t *testing.T) {
@@ -110,6 +113,202 @@ func Test_FilesystemResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      "lambdalabs_filesystem.test",
+				ImportState:       true,
+				ImportStateId:     filesystemName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func Test_FilesystemResource_DeleteInUse(t *testing.T) {
+	t.Parallel()
+
+	var inUse atomic.Bool
+	var deleteAttempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/file-systems":
+			resBody := fmt.Sprintf(`
+			{
+				"data": [
+					{
+						"id": "fs-12345678",
+						"name": "test-filesystem",
+						"mount_point": "/mnt/data",
+						"created": "2023-01-01T00:00:00.000Z",
+						"is_in_use": %t,
+						"region": { "name": "us-west-1" }
+					}
+				]
+			}
+			`, inUse.Load())
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/filesystems":
+			resBody := `
+			{
+				"data": {
+					"id": "fs-12345678",
+					"name": "test-filesystem",
+					"mount_point": "/mnt/data",
+					"created": "2023-01-01T00:00:00.000Z",
+					"is_in_use": false,
+					"region": { "name": "us-west-1" }
+				}
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/instances":
+			resBody := `
+			{
+				"data": [
+					{
+						"id": "0920582c7ff041399e34823a0be62549",
+						"status": "terminating",
+						"file_system_names": ["test-filesystem"]
+					}
+				]
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/filesystems/fs-12345678":
+			if deleteAttempts.Add(1) == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{ "error": { "code": "filesystems/filesystem-in-use", "message": "File system is in use." } }`)) //nolint:errcheck
+				return
+			}
+
+			w.Write([]byte(`{ "data": { "deleted_ids": ["fs-12345678"] } }`)) //nolint:errcheck
+		}
+	}))
+
+	config := providerConfig(server.URL) + `
+	resource "lambdalabs_filesystem" "test" {
+		name   = "test-filesystem"
+		region = "us-west-1"
+		timeouts {
+			delete = "2s"
+		}
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy: func(_ *terraform.State) error {
+			if deleteAttempts.Load() != 2 {
+				return fmt.Errorf("expected the in use error to be retried, got %d delete attempts", deleteAttempts.Load())
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_filesystem.test", "timeouts.delete", "2s"),
+				),
+			},
+			{
+				PreConfig: func() {
+					inUse.Store(true)
+				},
+				Config:      config,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`is still in use by instances 0920582c7ff041399e34823a0be62549 \(terminating\)`),
+			},
+			{
+				PreConfig: func() {
+					inUse.Store(false)
+				},
+				Config: config,
+			},
+		},
+	})
+}
+
+func Test_FilesystemResource_DeletionProtection(t *testing.T) {
+	t.Parallel()
+
+	var deleteAttempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/file-systems":
+			resBody := `
+			{
+				"data": [
+					{
+						"id": "fs-12345678",
+						"name": "checkpoints",
+						"mount_point": "/mnt/data",
+						"created": "2023-01-01T00:00:00.000Z",
+						"is_in_use": false,
+						"region": { "name": "us-west-1" }
+					}
+				]
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/filesystems":
+			resBody := `
+			{
+				"data": {
+					"id": "fs-12345678",
+					"name": "checkpoints",
+					"mount_point": "/mnt/data",
+					"created": "2023-01-01T00:00:00.000Z",
+					"is_in_use": false,
+					"region": { "name": "us-west-1" }
+				}
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/filesystems/fs-12345678":
+			deleteAttempts.Add(1)
+			w.Write([]byte(`{ "data": { "deleted_ids": ["fs-12345678"] } }`)) //nolint:errcheck
+		}
+	}))
+
+	config := func(region string, protected bool) string {
+		return providerConfig(server.URL) + fmt.Sprintf(`
+		resource "lambdalabs_filesystem" "test" {
+			name                = "checkpoints"
+			region              = %q
+			deletion_protection = %t
+		}
+		`, region, protected)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("us-west-1", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_filesystem.test", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      config("us-west-1", true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("Deletion protection enabled"),
+			},
+			{
+				Config:      config("us-east-1", true),
+				ExpectError: regexp.MustCompile("Deletion protection enabled"),
+			},
+			{
+				Config: config("us-west-1", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_filesystem.test", "deletion_protection", "false"),
+					func(_ *terraform.State) error {
+						if deleteAttempts.Load() != 0 {
+							return fmt.Errorf("expected protected file system to be kept, got %d delete attempts", deleteAttempts.Load())
+						}
+						return nil
+					},
+				),
+			},
 		},
 	})
 }
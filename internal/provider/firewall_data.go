@@ -92,7 +92,7 @@ func (d *firewallData) Read(ctx context.Context, req datasource.ReadRequest, res
 
 	res, err := d.client.ListFirewallRules(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to list firewall rules", err.Error())
+		resp.Diagnostics.AddError("failed to list firewall rules", errorDetail(err))
 		return
 	}
 
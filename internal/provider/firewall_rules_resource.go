@@ -0,0 +1,450 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/elct9620/terraform-provider-lambdalabs/pkg/lambdalabs"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	FirewallRulesOnDestroyRestore string = "restore"
+	FirewallRulesOnDestroyDefault string = "default"
+)
+
+const firewallRulesPrivateOriginal = "original_rules"
+
+var (
+	_ resource.Resource                   = &firewallRulesResource{}
+	_ resource.ResourceWithConfigure      = &firewallRulesResource{}
+	_ resource.ResourceWithValidateConfig = &firewallRulesResource{}
+)
+
+// defaultFirewallRules keeps the instances reachable by SSH when no default_rule is configured
+var defaultFirewallRules = []lambdalabs.FirewallRule{
+	{
+		Protocol:      "tcp",
+		PortRange:     []int{22, 22},
+		SourceNetwork: "0.0.0.0/0",
+		Description:   "Allow SSH",
+	},
+}
+
+type firewallRulesResource struct {
+	client *lambdalabs.Client
+}
+
+type firewallRulesModel struct {
+	ID           types.String        `tfsdk:"id"`
+	OnDestroy    types.String        `tfsdk:"on_destroy"`
+	Rules        []firewallRuleModel `tfsdk:"rule"`
+	DefaultRules []firewallRuleModel `tfsdk:"default_rule"`
+}
+
+func NewFirewallRulesResource() resource.Resource {
+	return &firewallRulesResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *firewallRulesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_rules"
+}
+
+// Schema defines the schema for the resource.
+func (r *firewallRulesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage the inbound firewall rules. The resource owns the complete rule list, " +
+			"rules added outside of Terraform are removed on the next apply. Only one instance of this resource should exist per account",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"on_destroy": schema.StringAttribute{
+				MarkdownDescription: "What to leave when the resource is destroyed, `restore` (default) restores the rules found at create " +
+					"and `default` replaces them with the `default_rule` blocks",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"rule":         firewallRuleBlock("An inbound firewall rule, the rules are compared regardless of order"),
+			"default_rule": firewallRuleBlock("A rule to leave when destroyed with `on_destroy` set to `default`, SSH from anywhere is allowed when omitted"),
+		},
+	}
+}
+
+func firewallRuleBlock(description string) schema.SetNestedBlock {
+	return schema.SetNestedBlock{
+		MarkdownDescription: description,
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"protocol": schema.StringAttribute{
+					MarkdownDescription: "The protocol, one of `tcp`, `udp`, `icmp` or `all`",
+					Required:            true,
+				},
+				"port_range": schema.ListAttribute{
+					MarkdownDescription: "The port range `[start, end]`, required for `tcp` and `udp`",
+					Optional:            true,
+					ElementType:         types.Int64Type,
+				},
+				"source_network": schema.StringAttribute{
+					MarkdownDescription: "The source network in CIDR notation, e.g. `0.0.0.0/0`",
+					Required:            true,
+				},
+				"description": schema.StringAttribute{
+					MarkdownDescription: "Description of the rule",
+					Optional:            true,
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *firewallRulesResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*lambdalabs.Client)
+}
+
+// ValidateConfig ensures the rules are accepted by the API before apply.
+func (r *firewallRulesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config firewallRulesModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.OnDestroy.IsNull() && !config.OnDestroy.IsUnknown() {
+		switch config.OnDestroy.ValueString() {
+		case FirewallRulesOnDestroyRestore, FirewallRulesOnDestroyDefault:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("on_destroy"),
+				"Invalid Lambdalabs firewall rules destroy action",
+				"The on_destroy must be one of "+FirewallRulesOnDestroyRestore+" or "+FirewallRulesOnDestroyDefault,
+			)
+		}
+	}
+
+	if len(config.DefaultRules) > 0 && config.OnDestroy.ValueString() != FirewallRulesOnDestroyDefault && !config.OnDestroy.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_rule"),
+			"Invalid Lambdalabs firewall rules destroy action",
+			"The default_rule blocks can only be used when on_destroy is "+FirewallRulesOnDestroyDefault,
+		)
+	}
+
+	for _, rules := range []struct {
+		name  string
+		rules []firewallRuleModel
+	}{{"rule", config.Rules}, {"default_rule", config.DefaultRules}} {
+		for _, rule := range rules.rules {
+			if err := validateFirewallRule(ctx, rule); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(rules.name),
+					"Invalid Lambdalabs firewall rule",
+					err.Error(),
+				)
+			}
+		}
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *firewallRulesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan firewallRulesModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The rules before Terraform takes over are kept out of the state, they are only needed to restore on destroy
+	original, err := r.client.ListFirewallRules(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating firewall rules",
+			"Could not list the current firewall rules, unexpected error: "+errorDetail(err),
+		)
+		return
+	}
+
+	raw, err := json.Marshal(original.Data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating firewall rules",
+			"Could not keep the current firewall rules to restore on destroy: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, firewallRulesPrivateOriginal, raw)...)
+
+	r.replaceRules(ctx, &plan, &resp.Diagnostics, "Error creating firewall rules")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *firewallRulesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state firewallRulesModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := r.client.ListFirewallRules(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Lambdalabs firewall rules",
+			"Could not list Lambdalabs firewall rules: "+errorDetail(err),
+		)
+		return
+	}
+
+	current, diags := firewallRulesFromModel(ctx, state.Rules)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured representation when only the order or empty values differ
+	if firewallRulesEqual(current, res.Data) {
+		return
+	}
+
+	state.Rules, diags = firewallRuleModelsFromAPI(ctx, res.Data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *firewallRulesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state firewallRulesModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	r.replaceRules(ctx, &plan, &resp.Diagnostics, "Error Update Lambdalabs firewall rules")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *firewallRulesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state firewallRulesModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules := defaultFirewallRules
+	if len(state.DefaultRules) > 0 {
+		var diags diag.Diagnostics
+		rules, diags = firewallRulesFromModel(ctx, state.DefaultRules)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if state.OnDestroy.ValueString() != FirewallRulesOnDestroyDefault {
+		raw, diags := req.Private.GetKey(ctx, firewallRulesPrivateOriginal)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// The original rules are missing when the resource was created by an older version, fall back to the default rules
+		if raw != nil {
+			rules = nil
+			if err := json.Unmarshal(raw, &rules); err != nil {
+				resp.Diagnostics.AddError(
+					"Error Deleting firewall rules",
+					"Could not read the firewall rules to restore: "+err.Error(),
+				)
+				return
+			}
+		}
+
+		// An account without rules is restored by sending an empty list
+		if rules == nil {
+			rules = []lambdalabs.FirewallRule{}
+		}
+	}
+
+	_, err := r.client.ReplaceFirewallRules(ctx, &lambdalabs.ReplaceFirewallRulesRequest{
+		Data: rules,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting firewall rules",
+			"Could not restore firewall rules, unexpected error: "+errorDetail(err),
+		)
+	}
+}
+
+func (r *firewallRulesResource) replaceRules(ctx context.Context, plan *firewallRulesModel, diags *diag.Diagnostics, summary string) {
+	rules, d := firewallRulesFromModel(ctx, plan.Rules)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	_, err := r.client.ReplaceFirewallRules(ctx, &lambdalabs.ReplaceFirewallRulesRequest{
+		Data: rules,
+	})
+	if err != nil {
+		diags.AddError(
+			summary,
+			"Could not replace firewall rules, unexpected error: "+errorDetail(err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue("firewall")
+}
+
+func validateFirewallRule(ctx context.Context, rule firewallRuleModel) error {
+	if rule.Protocol.IsUnknown() || rule.PortRange.IsUnknown() || rule.SourceNetwork.IsUnknown() {
+		return nil
+	}
+
+	protocol := rule.Protocol.ValueString()
+	switch protocol {
+	case "tcp", "udp":
+		if rule.PortRange.IsNull() {
+			return fmt.Errorf("the port_range is required for %s rules", protocol)
+		}
+	case "icmp", "all":
+		if !rule.PortRange.IsNull() {
+			return fmt.Errorf("the port_range cannot be used with %s rules", protocol)
+		}
+	default:
+		return fmt.Errorf("the protocol must be one of tcp, udp, icmp or all, got %q", protocol)
+	}
+
+	if _, _, err := net.ParseCIDR(rule.SourceNetwork.ValueString()); err != nil {
+		return fmt.Errorf("the source_network %q is not in CIDR notation", rule.SourceNetwork.ValueString())
+	}
+
+	if rule.PortRange.IsNull() {
+		return nil
+	}
+
+	var elements []types.Int64
+	if diags := rule.PortRange.ElementsAs(ctx, &elements, false); diags.HasError() {
+		return fmt.Errorf("the port_range must be a list of numbers")
+	}
+
+	ports := make([]int64, 0, len(elements))
+	for _, port := range elements {
+		if port.IsUnknown() {
+			return nil
+		}
+
+		ports = append(ports, port.ValueInt64())
+	}
+
+	if len(ports) != 2 || ports[0] < 1 || ports[1] > 65535 || ports[0] > ports[1] {
+		return fmt.Errorf("the port_range must be [start, end] between 1 and 65535, got %v", ports)
+	}
+
+	return nil
+}
+
+func firewallRulesFromModel(ctx context.Context, models []firewallRuleModel) ([]lambdalabs.FirewallRule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	rules := make([]lambdalabs.FirewallRule, 0, len(models))
+	for _, model := range models {
+		var ports []int64
+		if !model.PortRange.IsNull() {
+			diags.Append(model.PortRange.ElementsAs(ctx, &ports, false)...)
+		}
+
+		rule := lambdalabs.FirewallRule{
+			Protocol:      model.Protocol.ValueString(),
+			SourceNetwork: model.SourceNetwork.ValueString(),
+			Description:   model.Description.ValueString(),
+		}
+		for _, port := range ports {
+			rule.PortRange = append(rule.PortRange, int(port))
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, diags
+}
+
+// firewallRuleModelsFromAPI treats an empty description or port range as unset, the API returns them for every rule
+func firewallRuleModelsFromAPI(ctx context.Context, rules []lambdalabs.FirewallRule) ([]firewallRuleModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	models := make([]firewallRuleModel, 0, len(rules))
+	for _, rule := range rules {
+		model := firewallRuleModel{
+			Protocol:      types.StringValue(rule.Protocol),
+			PortRange:     types.ListNull(types.Int64Type),
+			SourceNetwork: types.StringValue(rule.SourceNetwork),
+			Description:   types.StringNull(),
+		}
+
+		if len(rule.PortRange) > 0 {
+			var d diag.Diagnostics
+			model.PortRange, d = types.ListValueFrom(ctx, types.Int64Type, rule.PortRange)
+			diags.Append(d...)
+		}
+
+		if rule.Description != "" {
+			model.Description = types.StringValue(rule.Description)
+		}
+
+		models = append(models, model)
+	}
+
+	return models, diags
+}
+
+// firewallRulesEqual compares the rules as a set, the API does not keep the order of the rules
+func firewallRulesEqual(a, b []lambdalabs.FirewallRule) bool {
+	return slices.Equal(firewallRuleKeys(a), firewallRuleKeys(b))
+}
+
+func firewallRuleKeys(rules []lambdalabs.FirewallRule) []string {
+	keys := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		keys = append(keys, fmt.Sprintf("%s|%v|%s|%s", rule.Protocol, rule.PortRange, rule.SourceNetwork, rule.Description))
+	}
+	slices.Sort(keys)
+
+	return slices.Compact(keys)
+}
@@ -0,0 +1,200 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+type firewallRulesServer struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	rules string
+}
+
+func newFirewallRulesServer(rules string) *firewallRulesServer {
+	s := &firewallRulesServer{rules: rules}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if r.URL.Path != "/firewall-rules" {
+			http.NotFoundHandler().ServeHTTP(w, r)
+			return
+		}
+
+		if r.Method == http.MethodPut {
+			var input struct {
+				Data json.RawMessage `json:"data"`
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &input) //nolint:errcheck
+			s.rules = string(input.Data)
+		}
+
+		w.Write([]byte(`{ "data": ` + s.rules + ` }`)) //nolint:errcheck
+	}))
+
+	return s
+}
+
+func (s *firewallRulesServer) Rules() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.rules
+}
+
+func (s *firewallRulesServer) SetRules(rules string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rules = rules
+}
+
+func Test_FirewallRulesResource(t *testing.T) {
+	t.Parallel()
+
+	original := `[{"protocol":"all","source_network":"0.0.0.0/0","description":""}]`
+	server := newFirewallRulesServer(original)
+
+	config := providerConfig(server.URL) + `
+	resource "lambdalabs_firewall_rules" "default" {
+		rule {
+			protocol       = "tcp"
+			port_range     = [22, 22]
+			source_network = "0.0.0.0/0"
+			description    = "Allow SSH"
+		}
+
+		rule {
+			protocol       = "tcp"
+			port_range     = [8888, 8888]
+			source_network = "10.0.0.0/8"
+		}
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy: func(_ *terraform.State) error {
+			if server.Rules() != original {
+				return fmt.Errorf("expected the original rules to be restored, got %s", server.Rules())
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_firewall_rules" "default" {
+					rule {
+						protocol       = "tcp"
+						source_network = "0.0.0.0/0"
+					}
+				}
+				`,
+				ExpectError: regexp.MustCompile("the port_range is required for tcp rules"),
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_firewall_rules.default", "id", "firewall"),
+					resource.TestCheckResourceAttr("lambdalabs_firewall_rules.default", "rule.#", "2"),
+					func(_ *terraform.State) error {
+						var rules []map[string]any
+						if err := json.Unmarshal([]byte(server.Rules()), &rules); err != nil || len(rules) != 2 {
+							return fmt.Errorf("expected the rules to be replaced, got %s", server.Rules())
+						}
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() {
+					server.SetRules(`[
+						{"protocol":"tcp","port_range":[8888,8888],"source_network":"10.0.0.0/8","description":""},
+						{"protocol":"tcp","port_range":[22,22],"source_network":"0.0.0.0/0","description":"Allow SSH"}
+					]`)
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				PreConfig: func() {
+					server.SetRules(`[
+						{"protocol":"tcp","port_range":[22,22],"source_network":"0.0.0.0/0","description":"Allow SSH"},
+						{"protocol":"tcp","port_range":[8888,8888],"source_network":"10.0.0.0/8","description":""},
+						{"protocol":"udp","port_range":[53,53],"source_network":"0.0.0.0/0","description":"Added in the dashboard"}
+					]`)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_firewall_rules.default", "rule.#", "2"),
+					func(_ *terraform.State) error {
+						if regexp.MustCompile(`udp`).MatchString(server.Rules()) {
+							return fmt.Errorf("expected the rule added outside of Terraform to be removed, got %s", server.Rules())
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func Test_FirewallRulesResource_OnDestroyDefault(t *testing.T) {
+	t.Parallel()
+
+	server := newFirewallRulesServer(`[]`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy: func(_ *terraform.State) error {
+			var rules []struct {
+				Protocol  string `json:"protocol"`
+				PortRange []int  `json:"port_range"`
+			}
+			if err := json.Unmarshal([]byte(server.Rules()), &rules); err != nil {
+				return err
+			}
+
+			if len(rules) != 1 || rules[0].Protocol != "tcp" || rules[0].PortRange[0] != 22 {
+				return fmt.Errorf("expected only SSH to be allowed after destroy, got %s", server.Rules())
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_firewall_rules" "default" {
+					on_destroy = "default"
+
+					rule {
+						protocol       = "icmp"
+						source_network = "0.0.0.0/0"
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_firewall_rules.default", "on_destroy", "default"),
+					resource.TestCheckResourceAttr("lambdalabs_firewall_rules.default", "rule.#", "1"),
+				),
+			},
+		},
+	})
+}
@@ -156,7 +156,7 @@ func (d *imageData) Read(ctx context.Context, req datasource.ReadRequest, resp *
 
 	res, err := d.client.ListImages(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to list images", err.Error())
+		resp.Diagnostics.AddError("failed to list images", errorDetail(err))
 		return
 	}
 
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+const importNamePrefix = "name:"
+
+type importCandidate struct {
+	ID   string
+	Name string
+}
+
+// importStateByName accepts an ID, `name:<value>` or a bare name which does not match any ID,
+// names are not unique in the API so an ambiguous name is rejected instead of importing an arbitrary match
+func importStateByName(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, kind string, list func(context.Context) ([]importCandidate, error)) {
+	candidates, err := list(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing "+kind,
+			"Could not list "+kind+"s to resolve "+req.ID+": "+errorDetail(err),
+		)
+		return
+	}
+
+	name, byName := strings.CutPrefix(req.ID, importNamePrefix)
+	if !byName {
+		for _, candidate := range candidates {
+			if candidate.ID == req.ID {
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), candidate.ID)...)
+				return
+			}
+		}
+	}
+
+	var ids []string
+	for _, candidate := range candidates {
+		if candidate.Name == name {
+			ids = append(ids, candidate.ID)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"Error importing "+kind,
+			"No "+kind+" with ID or name "+name+" was found",
+		)
+	case 1:
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[0])...)
+	default:
+		resp.Diagnostics.AddError(
+			"Error importing "+kind,
+			"Multiple "+kind+"s are named "+name+" ("+strings.Join(ids, ", ")+"), import by ID instead",
+		)
+	}
+}
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	api "github.com/elct9620/terraform-provider-lambdalabs/pkg/lambdalabs"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+var (
+	_ datasource.DataSource                   = &instanceData{}
+	_ datasource.DataSourceWithConfigure      = &instanceData{}
+	_ datasource.DataSourceWithValidateConfig = &instanceData{}
+)
+
+type instanceData struct {
+	client *api.Client
+}
+
+func NewInstanceData() datasource.DataSource {
+	return &instanceData{}
+}
+
+func (d *instanceData) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance"
+}
+
+func (d *instanceData) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := instanceDataAttributes()
+	attributes["id"] = schema.StringAttribute{
+		Description: "The instance ID, conflicts with name",
+		Optional:    true,
+		Computed:    true,
+	}
+	attributes["name"] = schema.StringAttribute{
+		Description: "The exact instance name, conflicts with id",
+		Optional:    true,
+		Computed:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Instance Data",
+		Attributes:          attributes,
+	}
+}
+
+func (d *instanceData) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*api.Client)
+}
+
+func (d *instanceData) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var model instanceDataModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.ID.IsUnknown() || model.Name.IsUnknown() {
+		return
+	}
+
+	if model.ID.IsNull() == model.Name.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"invalid instance lookup",
+			"Exactly one of id or name must be specified",
+		)
+	}
+}
+
+func (d *instanceData) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model instanceDataModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var instance *api.Instance
+	if !model.ID.IsNull() {
+		res, err := d.client.RetrieveInstance(ctx, &api.RetrieveInstanceRequest{
+			Id: model.ID.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("failed to retrieve instance", errorDetail(err))
+			return
+		}
+
+		instance = &res.Data
+	} else {
+		res, err := d.client.ListInstances(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("failed to list instances", errorDetail(err))
+			return
+		}
+
+		name := model.Name.ValueString()
+		var matchedIds []string
+		for i := range res.Data {
+			if res.Data[i].Name == name {
+				instance = &res.Data[i]
+				matchedIds = append(matchedIds, res.Data[i].ID)
+			}
+		}
+
+		if len(matchedIds) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"instance not found",
+				"The instance with name "+name+" not found",
+			)
+			return
+		}
+
+		if len(matchedIds) > 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"multiple instances found",
+				"The instance name "+name+" matches multiple instances ("+strings.Join(matchedIds, ", ")+"), use id to select one of them",
+			)
+			return
+		}
+	}
+
+	result, diags := newInstanceDataModel(ctx, *instance)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &result)...)
+}
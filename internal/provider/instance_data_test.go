@@ -0,0 +1,134 @@
+package provider_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func Test_InstanceData(t *testing.T) {
+	t.Parallel()
+
+	instanceBody := `
+	{
+		"id": "0920582c7ff041399e34823a0be62549",
+		"name": "training-node-1",
+		"ip": "10.10.10.1",
+		"status": "active",
+		"ssh_key_names": ["terraform"],
+		"file_system_names": [],
+		"region": {
+			"name": "us-west-1",
+			"description": "California, USA"
+		},
+		"instance_type": {
+			"name": "gpu_1x_a100",
+			"description": "1x A100 (40 GB SXM4)",
+			"gpu_description": "A100 (40 GB SXM4)",
+			"price_cents_per_hour": 129,
+			"specs": {
+				"vcpus": 30,
+				"memory_gib": 200,
+				"storage_gib": 512,
+				"gpus": 1
+			}
+		}
+	}
+	`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/instances":
+			resBody := `
+			{
+				"data": [
+					` + instanceBody + `,
+					{
+						"id": "1920582c7ff041399e34823a0be62549",
+						"name": "worker",
+						"ip": "10.10.10.2",
+						"status": "active",
+						"ssh_key_names": ["terraform"],
+						"region": { "name": "us-west-1" },
+						"instance_type": { "name": "gpu_1x_a10" }
+					},
+					{
+						"id": "2920582c7ff041399e34823a0be62549",
+						"name": "worker",
+						"ip": "10.10.10.3",
+						"status": "active",
+						"ssh_key_names": ["terraform"],
+						"region": { "name": "us-west-1" },
+						"instance_type": { "name": "gpu_1x_a10" }
+					}
+				]
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/instances/0920582c7ff041399e34823a0be62549":
+			w.Write([]byte(`{ "data": ` + instanceBody + ` }`)) //nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{ "error": { "code": "global/object-does-not-exist", "message": "Specified instance does not exist." } }`)) //nolint:errcheck
+		}
+	}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+				data "lambdalabs_instance" "by_id" {
+					id = "0920582c7ff041399e34823a0be62549"
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.lambdalabs_instance.by_id", "name", "training-node-1"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instance.by_id", "ip", "10.10.10.1"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instance.by_id", "region.name", "us-west-1"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instance.by_id", "instance_type.name", "gpu_1x_a100"),
+				),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				data "lambdalabs_instance" "by_name" {
+					name = "training-node-1"
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.lambdalabs_instance.by_name", "id", "0920582c7ff041399e34823a0be62549"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instance.by_name", "ip", "10.10.10.1"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instance.by_name", "ssh_key_names.0", "terraform"),
+				),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				data "lambdalabs_instance" "duplicated" {
+					name = "worker"
+				}
+				`,
+				ExpectError: regexp.MustCompile("multiple instances found"),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				data "lambdalabs_instance" "missing" {
+					name = "not-exists"
+				}
+				`,
+				ExpectError: regexp.MustCompile("instance not found"),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				data "lambdalabs_instance" "ambiguous" {
+					id   = "0920582c7ff041399e34823a0be62549"
+					name = "training-node-1"
+				}
+				`,
+				ExpectError: regexp.MustCompile("Exactly one of id or name must be specified"),
+			},
+		},
+	})
+}
@@ -0,0 +1,640 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elct9620/terraform-provider-lambdalabs/pkg/lambdalabs"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_                                 resource.Resource                   = &instanceGroupResource{}
+	_                                 resource.ResourceWithConfigure      = &instanceGroupResource{}
+	_                                 resource.ResourceWithValidateConfig = &instanceGroupResource{}
+	_                                 resource.ResourceWithModifyPlan     = &instanceGroupResource{}
+	defaultInstanceGroupCreateTimeout                                     = 20 * time.Minute
+	defaultInstanceGroupUpdateTimeout                                     = 20 * time.Minute
+	defaultInstanceGroupDeleteTimeout                                     = 10 * time.Minute
+)
+
+type instanceGroupResource struct {
+	client *lambdalabs.Client
+}
+
+type instanceGroupModel struct {
+	ID                 types.String        `tfsdk:"id"`
+	Name               types.String        `tfsdk:"name"`
+	Quantity           types.Int64         `tfsdk:"quantity"`
+	RegionName         types.String        `tfsdk:"region_name"`
+	InstanceTypeName   types.String        `tfsdk:"instance_type_name"`
+	SSHKeyNames        types.List          `tfsdk:"ssh_key_names"`
+	FileSystemNames    types.List          `tfsdk:"file_system_names"`
+	UserData           types.String        `tfsdk:"user_data"`
+	UserDataHash       types.String        `tfsdk:"user_data_hash"`
+	Image              *instanceImageModel `tfsdk:"image"`
+	DeletionProtection types.Bool          `tfsdk:"deletion_protection"`
+	InstanceIDs        types.List          `tfsdk:"instance_ids"`
+	IPs                types.List          `tfsdk:"ips"`
+	PrivateIPs         types.List          `tfsdk:"private_ips"`
+	Timeouts           timeouts.Value      `tfsdk:"timeouts"`
+}
+
+func NewInstanceGroupResource() resource.Resource {
+	return &instanceGroupResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *instanceGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_group"
+}
+
+// Schema defines the schema for the resource.
+func (r *instanceGroupResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage a group of identical instances launched together. " +
+			"Changing `quantity` launches or terminates the difference, the most recently launched members are terminated first",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The instance group ID, it is generated by the provider",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of every member, changes are applied in place",
+				Optional:            true,
+			},
+			"quantity": schema.Int64Attribute{
+				MarkdownDescription: "The number of instances, changes scale the group in place",
+				Required:            true,
+			},
+			"region_name": schema.StringAttribute{
+				MarkdownDescription: "The region name of the members",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"instance_type_name": schema.StringAttribute{
+				MarkdownDescription: "The instance type name of the members",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ssh_key_names": schema.ListAttribute{
+				MarkdownDescription: "The SSH Key names to install into the members",
+				Required:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"file_system_names": schema.ListAttribute{
+				MarkdownDescription: "Optional list of file system names to attach to the members",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"deletion_protection": deletionProtectionAttribute("instance group"),
+			"user_data":           userDataAttribute(),
+			"user_data_hash":      userDataHashAttribute(),
+			"instance_ids": schema.ListAttribute{
+				MarkdownDescription: "The member instance IDs in launch order",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"ips": schema.ListAttribute{
+				MarkdownDescription: "The public IP addresses of the members, in the same order as `instance_ids`",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"private_ips": schema.ListAttribute{
+				MarkdownDescription: "The private IP addresses of the members, in the same order as `instance_ids`",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"image": instanceImageBlock("members"),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *instanceGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*lambdalabs.Client)
+}
+
+// ValidateConfig ensures the quantity, user data and image selector can be launched.
+func (r *instanceGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config instanceGroupModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Quantity.IsNull() && !config.Quantity.IsUnknown() && config.Quantity.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("quantity"),
+			"Invalid Lambdalabs instance group quantity",
+			"The quantity must be at least 1, destroy the group to terminate every member",
+		)
+	}
+
+	validateUserDataAttribute(&resp.Diagnostics, config.UserData)
+	validateInstanceImageSelector(&resp.Diagnostics, config.Image)
+}
+
+// ModifyPlan keeps the members known while the group is not scaled and guards the replacement.
+func (r *instanceGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		var state instanceGroupModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		checkDeletionProtection(&resp.Diagnostics, state.DeletionProtection, "instance group", state.ID.ValueString())
+		return
+	}
+
+	var plan, state instanceGroupModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.UserDataHash = planUserDataHash(ctx, req, resp, state.UserDataHash, false)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client != nil && (req.State.Raw.IsNull() || !reflect.DeepEqual(plan.Image, state.Image) ||
+		!plan.RegionName.Equal(state.RegionName) || !plan.InstanceTypeName.Equal(state.InstanceTypeName)) {
+		candidates := []instancePlacementModel{{RegionName: plan.RegionName, InstanceTypeName: plan.InstanceTypeName}}
+		validateInstanceImage(ctx, r.client, plan.Image, candidates, &resp.Diagnostics)
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	if len(resp.RequiresReplace) > 0 || instanceRequiresReplace(plan.launchOptions(), state.launchOptions()) {
+		checkDeletionProtection(&resp.Diagnostics, state.DeletionProtection, "instance group", state.ID.ValueString())
+		return
+	}
+
+	// Renaming the members does not change them, only scaling does
+	if plan.Quantity.Equal(state.Quantity) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("instance_ids"), state.InstanceIDs)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ips"), state.IPs)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("private_ips"), state.PrivateIPs)...)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *instanceGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var group instanceGroupModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &group)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := group.Timeouts.Create(ctx, defaultInstanceGroupCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var userData types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("user_data"), &userData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiReq, diags := group.launchRequest(ctx, userData, int(group.Quantity.ValueInt64()))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating instance group",
+			"Could not generate the instance group ID, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	group.ID = types.StringValue(hex.EncodeToString(id))
+
+	instances, err := r.launchMembers(ctx, apiReq, createTimeout)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating instance group",
+			"Could not create instance group, unexpected error: "+errorDetail(err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(group.setMembers(ctx, instances)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, group)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *instanceGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state instanceGroupModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(state.InstanceIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := r.client.ListInstances(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Lambdalabs instance group",
+			"Could not list instances of group ID "+state.ID.ValueString()+": "+errorDetail(err),
+		)
+		return
+	}
+
+	running := make(map[string]*lambdalabs.Instance, len(res.Data))
+	for i := range res.Data {
+		running[res.Data[i].ID] = &res.Data[i]
+	}
+
+	// Members terminated outside of Terraform are dropped, the quantity drift plans to launch them again
+	instances := make([]*lambdalabs.Instance, 0, len(ids))
+	for _, id := range ids {
+		instance, ok := running[id]
+		if !ok || instance.Status == InstanceStateTerminated {
+			continue
+		}
+
+		instances = append(instances, instance)
+	}
+
+	if len(instances) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Name = instanceNameValue(state.Name, instances[0].Name)
+	state.Quantity = types.Int64Value(int64(len(instances)))
+	resp.Diagnostics.Append(state.setMembers(ctx, instances)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update renames the members and scales the group to the planned quantity.
+func (r *instanceGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state instanceGroupModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultInstanceGroupUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(state.InstanceIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The planned members are unknown while scaling, keep tracking the current members when the update fails
+	defer func() {
+		if resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+		}
+	}()
+
+	plan.ID = state.ID
+
+	quantity := int(plan.Quantity.ValueInt64())
+	switch {
+	case quantity > len(ids):
+		var userData types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("user_data"), &userData)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		apiReq, diags := plan.launchRequest(ctx, userData, quantity-len(ids))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		launched, err := r.launchMembers(ctx, apiReq, updateTimeout)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Update Lambdalabs instance group",
+				"Could not scale instance group ID "+state.ID.ValueString()+" to "+strconv.Itoa(quantity)+" instances: "+errorDetail(err),
+			)
+			return
+		}
+
+		// The launched members are tracked right away to not leave them running untracked when renaming fails
+		state.Quantity = plan.Quantity
+		resp.Diagnostics.Append(state.appendMembers(ctx, launched)...)
+	case quantity < len(ids):
+		if err := terminateInstances(ctx, r.client, ids[quantity:], updateTimeout); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Update Lambdalabs instance group",
+				"Could not scale instance group ID "+state.ID.ValueString()+" to "+strconv.Itoa(quantity)+" instances: "+errorDetail(err),
+			)
+			return
+		}
+
+		state.Quantity = plan.Quantity
+		resp.Diagnostics.Append(state.truncateMembers(ctx, quantity)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The launched members already have the planned name, only the members kept from before are renamed
+	if !plan.Name.Equal(state.Name) {
+		for _, id := range ids[:min(quantity, len(ids))] {
+			_, err := r.client.UpdateInstance(ctx, &lambdalabs.UpdateInstanceRequest{
+				Id:   id,
+				Name: plan.Name.ValueString(),
+			})
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Update Lambdalabs instance group",
+					"Could not rename instance ID "+id+": "+errorDetail(err),
+				)
+				return
+			}
+		}
+	}
+
+	plan.InstanceIDs = state.InstanceIDs
+	plan.IPs = state.IPs
+	plan.PrivateIPs = state.PrivateIPs
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *instanceGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state instanceGroupModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkDeletionProtection(&resp.Diagnostics, state.DeletionProtection, "instance group", state.ID.ValueString())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultInstanceGroupDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(state.InstanceIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := terminateInstances(ctx, r.client, ids, deleteTimeout); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting instance group",
+			"Could not delete instance group ID "+state.ID.ValueString()+", unexpected error: "+errorDetail(err),
+		)
+	}
+}
+
+// launchMembers launches the instances in a single request to avoid racing for the capacity,
+// a launch is all or nothing so the members which boot are terminated when any of them fails
+func (r *instanceGroupResource) launchMembers(ctx context.Context, apiReq *lambdalabs.LaunchInstanceRequest, timeout time.Duration) ([]*lambdalabs.Instance, error) {
+	deadline := time.Now().Add(timeout)
+
+	res, err := r.client.LaunchInstance(ctx, apiReq)
+	if err != nil {
+		return nil, err
+	}
+
+	instances, err := waitInstancesCreated(ctx, r.client, res.Data.IDs, time.Until(deadline))
+	if err == nil && len(instances) != apiReq.Quantity {
+		err = errors.New("launched " + strconv.Itoa(len(instances)) + " of " + strconv.Itoa(apiReq.Quantity) + " instances")
+	}
+
+	if err != nil {
+		if cleanupErr := terminateInstances(ctx, r.client, res.Data.IDs, time.Until(deadline)); cleanupErr != nil {
+			return nil, errors.New(err.Error() + ", and could not terminate the launched instance IDs " +
+				strings.Join(res.Data.IDs, ", ") + ", terminate them manually to stop the billing: " + errorDetail(cleanupErr))
+		}
+
+		return nil, err
+	}
+
+	return instances, nil
+}
+
+// waitInstancesCreated waits for every instance in parallel, the instances are returned in the same order as the IDs
+func waitInstancesCreated(ctx context.Context, client *lambdalabs.Client, ids []string, timeout time.Duration) ([]*lambdalabs.Instance, error) {
+	instances := make([]*lambdalabs.Instance, len(ids))
+	errs := make([]error, len(ids))
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			instances[i], errs[i] = waitInstanceCreated(ctx, client, id, timeout)
+			if errs[i] != nil {
+				errs[i] = errors.New("instance ID " + id + ": " + errs[i].Error())
+			}
+		}()
+	}
+	wg.Wait()
+
+	return instances, errors.Join(errs...)
+}
+
+// terminateInstances terminates the instances in a single request and waits for them in parallel
+func terminateInstances(ctx context.Context, client *lambdalabs.Client, ids []string, timeout time.Duration) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := client.TerminateInstance(ctx, &lambdalabs.TerminateInstanceRequest{
+		Ids: ids,
+	})
+	if err != nil && !errors.Is(err, lambdalabs.ErrNotFound) {
+		return err
+	}
+
+	errs := make([]error, len(ids))
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := waitInstanceTerminated(ctx, client, id, timeout); err != nil {
+				errs[i] = errors.New("instance ID " + id + ": " + err.Error())
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func (m *instanceGroupModel) launchOptions() instanceLaunchOptions {
+	return instanceLaunchOptions{
+		RegionName:       m.RegionName,
+		InstanceTypeName: m.InstanceTypeName,
+		SSHKeyNames:      m.SSHKeyNames,
+		FileSystemNames:  m.FileSystemNames,
+		Image:            m.Image,
+	}
+}
+
+func (m *instanceGroupModel) launchRequest(ctx context.Context, userData types.String, quantity int) (*lambdalabs.LaunchInstanceRequest, diag.Diagnostics) {
+	apiReq := &lambdalabs.LaunchInstanceRequest{
+		RegionName:       m.RegionName.ValueString(),
+		InstanceTypeName: m.InstanceTypeName.ValueString(),
+		SSHKeyNames:      []string{},
+		UserData:         userData.ValueString(),
+		Image:            m.Image.launchImage(),
+		Quantity:         quantity,
+	}
+
+	diags := m.SSHKeyNames.ElementsAs(ctx, &apiReq.SSHKeyNames, false)
+	if !m.FileSystemNames.IsNull() {
+		diags.Append(m.FileSystemNames.ElementsAs(ctx, &apiReq.FileSystemNames, false)...)
+	}
+
+	if !m.Name.IsNull() {
+		name := m.Name.ValueString()
+		apiReq.Name = &name
+	}
+
+	return apiReq, diags
+}
+
+func (m *instanceGroupModel) setMembers(ctx context.Context, instances []*lambdalabs.Instance) diag.Diagnostics {
+	ids := make([]string, 0, len(instances))
+	ips := make([]string, 0, len(instances))
+	privateIPs := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		ids = append(ids, instance.ID)
+		ips = append(ips, instance.IP)
+		privateIPs = append(privateIPs, instance.PrivateIP)
+	}
+
+	return m.setMemberLists(ctx, ids, ips, privateIPs)
+}
+
+// appendMembers adds the launched instances after the current members without reading the current members again
+func (m *instanceGroupModel) appendMembers(ctx context.Context, instances []*lambdalabs.Instance) diag.Diagnostics {
+	ids, ips, privateIPs, diags := m.memberLists(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, instance := range instances {
+		ids = append(ids, instance.ID)
+		ips = append(ips, instance.IP)
+		privateIPs = append(privateIPs, instance.PrivateIP)
+	}
+
+	diags.Append(m.setMemberLists(ctx, ids, ips, privateIPs)...)
+	return diags
+}
+
+// truncateMembers keeps the first quantity members, the most recently launched members are terminated first
+func (m *instanceGroupModel) truncateMembers(ctx context.Context, quantity int) diag.Diagnostics {
+	ids, ips, privateIPs, diags := m.memberLists(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(m.setMemberLists(ctx, ids[:quantity], ips[:quantity], privateIPs[:quantity])...)
+	return diags
+}
+
+func (m *instanceGroupModel) memberLists(ctx context.Context) ([]string, []string, []string, diag.Diagnostics) {
+	var ids, ips, privateIPs []string
+
+	diags := m.InstanceIDs.ElementsAs(ctx, &ids, false)
+	diags.Append(m.IPs.ElementsAs(ctx, &ips, false)...)
+	diags.Append(m.PrivateIPs.ElementsAs(ctx, &privateIPs, false)...)
+
+	return ids, ips, privateIPs, diags
+}
+
+func (m *instanceGroupModel) setMemberLists(ctx context.Context, ids, ips, privateIPs []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var d diag.Diagnostics
+	m.InstanceIDs, d = types.ListValueFrom(ctx, types.StringType, ids)
+	diags.Append(d...)
+	m.IPs, d = types.ListValueFrom(ctx, types.StringType, ips)
+	diags.Append(d...)
+	m.PrivateIPs, d = types.ListValueFrom(ctx, types.StringType, privateIPs)
+	diags.Append(d...)
+
+	return diags
+}
@@ -0,0 +1,220 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func Test_InstanceGroupResource(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var launches []int
+	var terminated []string
+	names := map[string]string{}
+	running := []string{}
+
+	member := func(id string) string {
+		n := strings.TrimPrefix(id, "member-")
+		return fmt.Sprintf(`{
+			"id": %q,
+			"name": %q,
+			"ip": "10.10.10.%s",
+			"private_ip": "172.16.0.%s",
+			"status": "active",
+			"ssh_key_names": ["terraform"],
+			"region": { "name": "us-tx-1" },
+			"instance_type": { "name": "gpu_8x_h100_sxm5" }
+		}`, id, names[id], n, n)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch path := strings.TrimSpace(r.URL.Path); {
+		case path == "/instances":
+			members := make([]string, 0, len(running))
+			for _, id := range running {
+				members = append(members, member(id))
+			}
+
+			w.Write([]byte(`{ "data": [` + strings.Join(members, ",") + `] }`)) //nolint:errcheck
+		case strings.HasPrefix(path, "/instances/"):
+			id := strings.TrimPrefix(path, "/instances/")
+			if !slices.Contains(running, id) {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{ "error": { "code": "global/object-does-not-exist", "message": "Specified instance does not exist." } }`)) //nolint:errcheck
+				return
+			}
+
+			if r.Method == http.MethodPost {
+				var input struct {
+					Name string `json:"name"`
+				}
+
+				body, _ := io.ReadAll(r.Body)
+				json.Unmarshal(body, &input) //nolint:errcheck
+				names[id] = input.Name
+			}
+
+			w.Write([]byte(`{ "data": ` + member(id) + ` }`)) //nolint:errcheck
+		case path == "/instance-operations/launch":
+			var input struct {
+				Name     string `json:"name"`
+				Quantity int    `json:"quantity"`
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &input) //nolint:errcheck
+			launches = append(launches, input.Quantity)
+
+			ids := []string{}
+			for range input.Quantity {
+				id := fmt.Sprintf("member-%d", len(running)+len(terminated)+1)
+				names[id] = input.Name
+				running = append(running, id)
+				ids = append(ids, fmt.Sprintf("%q", id))
+			}
+
+			w.Write([]byte(`{ "data": { "instance_ids": [` + strings.Join(ids, ",") + `] } }`)) //nolint:errcheck
+		case path == "/instance-operations/terminate":
+			var input struct {
+				IDs []string `json:"instance_ids"`
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &input) //nolint:errcheck
+
+			members := []string{}
+			for _, id := range input.IDs {
+				members = append(members, member(id))
+				running = slices.DeleteFunc(running, func(v string) bool { return v == id })
+				terminated = append(terminated, id)
+			}
+
+			w.Write([]byte(`{ "data": { "terminated_instances": [` + strings.Join(members, ",") + `] } }`)) //nolint:errcheck
+		default:
+			http.NotFoundHandler().ServeHTTP(w, r)
+		}
+	}))
+
+	config := func(name string, quantity int) string {
+		return providerConfig(server.URL) + fmt.Sprintf(`
+		resource "lambdalabs_instance_group" "default" {
+			name               = %q
+			quantity           = %d
+			region_name        = "us-tx-1"
+			instance_type_name = "gpu_8x_h100_sxm5"
+			ssh_key_names = [
+				"terraform"
+			]
+		}
+		`, name, quantity)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy: func(_ *terraform.State) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if len(running) > 0 {
+				return fmt.Errorf("expected every member to be terminated, got %v running", running)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      config("trainer", 0),
+				ExpectError: regexp.MustCompile("The quantity must be at least 1"),
+			},
+			{
+				Config: config("trainer", 3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("lambdalabs_instance_group.default", "id"),
+					resource.TestCheckResourceAttr("lambdalabs_instance_group.default", "instance_ids.#", "3"),
+					resource.TestCheckResourceAttr("lambdalabs_instance_group.default", "instance_ids.0", "member-1"),
+					resource.TestCheckResourceAttr("lambdalabs_instance_group.default", "ips.2", "10.10.10.3"),
+					resource.TestCheckResourceAttr("lambdalabs_instance_group.default", "private_ips.2", "172.16.0.3"),
+					func(_ *terraform.State) error {
+						mu.Lock()
+						defer mu.Unlock()
+
+						if !slices.Equal(launches, []int{3}) {
+							return fmt.Errorf("expected a single launch of 3 instances, got %v", launches)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: config("trainer", 5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance_group.default", "quantity", "5"),
+					resource.TestCheckResourceAttr("lambdalabs_instance_group.default", "instance_ids.#", "5"),
+					resource.TestCheckResourceAttr("lambdalabs_instance_group.default", "instance_ids.0", "member-1"),
+					resource.TestCheckResourceAttr("lambdalabs_instance_group.default", "instance_ids.4", "member-5"),
+					func(_ *terraform.State) error {
+						mu.Lock()
+						defer mu.Unlock()
+
+						if !slices.Equal(launches, []int{3, 2}) {
+							return fmt.Errorf("expected only the difference to be launched, got %v", launches)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: config("trainer-v2", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance_group.default", "name", "trainer-v2"),
+					resource.TestCheckResourceAttr("lambdalabs_instance_group.default", "instance_ids.#", "2"),
+					resource.TestCheckResourceAttr("lambdalabs_instance_group.default", "instance_ids.1", "member-2"),
+					resource.TestCheckResourceAttr("lambdalabs_instance_group.default", "ips.#", "2"),
+					func(_ *terraform.State) error {
+						mu.Lock()
+						defer mu.Unlock()
+
+						if !slices.Equal(terminated, []string{"member-3", "member-4", "member-5"}) {
+							return fmt.Errorf("expected the last launched members to be terminated, got %v", terminated)
+						}
+
+						if names["member-1"] != "trainer-v2" || names["member-2"] != "trainer-v2" {
+							return fmt.Errorf("expected the remaining members to be renamed, got %v", names)
+						}
+
+						if names["member-3"] != "trainer" {
+							return fmt.Errorf("expected the terminated members not to be renamed, got %v", names)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() {
+					mu.Lock()
+					defer mu.Unlock()
+
+					running = slices.DeleteFunc(running, func(v string) bool { return v == "member-2" })
+					terminated = append(terminated, "member-2")
+				},
+				Config:             config("trainer-v2", 2),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
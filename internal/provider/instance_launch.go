@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/elct9620/terraform-provider-lambdalabs/pkg/lambdalabs"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type instanceImageModel struct {
+	ID     types.String `tfsdk:"id"`
+	Family types.String `tfsdk:"family"`
+}
+
+// instanceLaunchOptions are the launch request fields shared by the instance and instance group resources,
+// none of them can be changed after launch
+type instanceLaunchOptions struct {
+	RegionName       types.String
+	InstanceTypeName types.String
+	SSHKeyNames      types.List
+	FileSystemNames  types.List
+	Image            *instanceImageModel
+}
+
+func instanceImageBlock(target string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "The image to boot the " + target + " from, the Lambda Stack image is used when omitted. " +
+			"The image is checked at plan time to be available in the launch region",
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The image ID, conflicts with `family`",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"family": schema.StringAttribute{
+				MarkdownDescription: "The image family, the latest image of the family is used, conflicts with `id`",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func validateInstanceImageSelector(diags *diag.Diagnostics, image *instanceImageModel) {
+	if image == nil || image.ID.IsUnknown() || image.Family.IsUnknown() {
+		return
+	}
+
+	if image.ID.IsNull() == image.Family.IsNull() {
+		diags.AddAttributeError(
+			path.Root("image"),
+			"Invalid Lambdalabs instance image",
+			"Exactly one of image id or family must be specified",
+		)
+	}
+}
+
+// validateInstanceImage checks the image is available in the region of every placement candidate. The architecture is
+// inferred from the instance type name, so a mismatch is only a warning to avoid rejecting images for unknown instance types
+func validateInstanceImage(ctx context.Context, client *lambdalabs.Client, image *instanceImageModel, candidates []instancePlacementModel, diags *diag.Diagnostics) {
+	if image == nil || image.ID.IsUnknown() || image.Family.IsUnknown() {
+		return
+	}
+
+	for _, candidate := range candidates {
+		if candidate.RegionName.IsUnknown() || candidate.InstanceTypeName.IsUnknown() {
+			return
+		}
+	}
+
+	res, err := client.ListImages(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error validating instance image",
+			"Could not list images: "+errorDetail(err),
+		)
+		return
+	}
+
+	selector := "family " + image.Family.ValueString()
+	if !image.ID.IsNull() {
+		selector = "id " + image.ID.ValueString()
+	}
+
+	for _, candidate := range candidates {
+		region := candidate.RegionName.ValueString()
+		instanceTypeName := candidate.InstanceTypeName.ValueString()
+
+		var architectures []string
+		for _, available := range res.Data {
+			if available.Region.Name != region {
+				continue
+			}
+
+			if (!image.ID.IsNull() && available.ID == image.ID.ValueString()) || (!image.Family.IsNull() && available.Family == image.Family.ValueString()) {
+				architectures = append(architectures, available.Architecture)
+			}
+		}
+
+		if len(architectures) == 0 {
+			diags.AddAttributeError(
+				path.Root("image"),
+				"Invalid Lambdalabs instance image",
+				"No image with "+selector+" is available in region "+region+", use the lambdalabs_images data source to list available images",
+			)
+			continue
+		}
+
+		if architecture := instanceTypeArchitecture(instanceTypeName); !slices.Contains(architectures, architecture) {
+			diags.AddAttributeWarning(
+				path.Root("image"),
+				"Lambdalabs instance image may not match the instance type",
+				"The image with "+selector+" in region "+region+" is built for "+strings.Join(architectures, ", ")+
+					" but "+instanceTypeName+" is assumed to be "+architecture+" from its name",
+			)
+		}
+	}
+}
+
+// instanceTypeArchitecture infers the CPU architecture because the API does not expose it on instance types,
+// the GH200 Grace Hopper is currently the only ARM instance type
+func instanceTypeArchitecture(instanceTypeName string) string {
+	if strings.Contains(instanceTypeName, "gh200") {
+		return "arm64"
+	}
+
+	return "x86_64"
+}
+
+// instanceRequiresReplace mirrors the RequiresReplace attribute plan modifiers which are not visible to ModifyPlan
+func instanceRequiresReplace(plan, state instanceLaunchOptions) bool {
+	return !plan.RegionName.Equal(state.RegionName) ||
+		!plan.InstanceTypeName.Equal(state.InstanceTypeName) ||
+		!plan.SSHKeyNames.Equal(state.SSHKeyNames) ||
+		!plan.FileSystemNames.Equal(state.FileSystemNames) ||
+		!reflect.DeepEqual(plan.Image, state.Image)
+}
+
+func (m *instanceImageModel) launchImage() *lambdalabs.LaunchInstanceImage {
+	if m == nil {
+		return nil
+	}
+
+	return &lambdalabs.LaunchInstanceImage{
+		ID:     m.ID.ValueString(),
+		Family: m.Family.ValueString(),
+	}
+}
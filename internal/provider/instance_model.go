@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+
+	api "github.com/elct9620/terraform-provider-lambdalabs/pkg/lambdalabs"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// instanceRegionModel represents a region where an instance is running
+type instanceRegionModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+// instanceDataModel represents an instance with all its attributes
+type instanceDataModel struct {
+	ID              types.String         `tfsdk:"id"`
+	Name            types.String         `tfsdk:"name"`
+	IP              types.String         `tfsdk:"ip"`
+	Status          types.String         `tfsdk:"status"`
+	SSHKeyNames     types.List           `tfsdk:"ssh_key_names"`
+	FileSystemNames types.List           `tfsdk:"file_system_names"`
+	Region          *instanceRegionModel `tfsdk:"region"`
+	InstanceType    *instanceTypeModel   `tfsdk:"instance_type"`
+}
+
+// instancesFilterModel represents filtering options for instances
+type instancesFilterModel struct {
+	Region         types.String `tfsdk:"region"`
+	InstanceType   types.String `tfsdk:"instance_type"`
+	Status         types.String `tfsdk:"status"`
+	NameRegex      types.String `tfsdk:"name_regex"`
+	FileSystemName types.String `tfsdk:"file_system_name"`
+}
+
+// instancesDataModel represents the data source model for instances
+type instancesDataModel struct {
+	ID        types.String          `tfsdk:"id"`
+	Filter    *instancesFilterModel `tfsdk:"filter"`
+	Instances []instanceDataModel   `tfsdk:"instances"`
+}
+
+func newInstanceDataModel(ctx context.Context, instance api.Instance) (instanceDataModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	sshKeyNames, d := types.ListValueFrom(ctx, types.StringType, nonNilStrings(instance.SSHKeyNames))
+	diags.Append(d...)
+
+	fileSystemNames, d := types.ListValueFrom(ctx, types.StringType, nonNilStrings(instance.FileSystemNames))
+	diags.Append(d...)
+
+	return instanceDataModel{
+		ID:              types.StringValue(instance.ID),
+		Name:            types.StringValue(instance.Name),
+		IP:              types.StringValue(instance.IP),
+		Status:          types.StringValue(instance.Status),
+		SSHKeyNames:     sshKeyNames,
+		FileSystemNames: fileSystemNames,
+		Region: &instanceRegionModel{
+			Name:        types.StringValue(instance.Region.Name),
+			Description: types.StringValue(instance.Region.Description),
+		},
+		InstanceType: &instanceTypeModel{
+			Name:              types.StringValue(instance.InstanceType.Name),
+			Description:       types.StringValue(instance.InstanceType.Description),
+			GPUDescription:    types.StringValue(instance.InstanceType.GPUDescription),
+			PriceCentsPerHour: types.Int64Value(int64(instance.InstanceType.PriceCentsPerHour)),
+			Specs: &instanceTypeSpecsModel{
+				VCPUs:      types.Int64Value(int64(instance.InstanceType.Specs.VCPUs)),
+				MemoryGiB:  types.Int64Value(int64(instance.InstanceType.Specs.MemoryGiB)),
+				StorageGiB: types.Int64Value(int64(instance.InstanceType.Specs.StorageGiB)),
+				GPUs:       types.Int64Value(int64(instance.InstanceType.Specs.GPUs)),
+			},
+		},
+	}, diags
+}
+
+// instanceDataAttributes returns the computed attributes describing an instance
+func instanceDataAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "Instance ID",
+			Computed:    true,
+		},
+		"name": schema.StringAttribute{
+			Description: "Instance name",
+			Computed:    true,
+		},
+		"ip": schema.StringAttribute{
+			Description: "Public IP address",
+			Computed:    true,
+		},
+		"status": schema.StringAttribute{
+			Description: "Instance status",
+			Computed:    true,
+		},
+		"ssh_key_names": schema.ListAttribute{
+			Description: "SSH Key names installed into the instance",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+		"file_system_names": schema.ListAttribute{
+			Description: "File system names attached to the instance",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+		"region": schema.SingleNestedAttribute{
+			Description: "Region information",
+			Computed:    true,
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Description: "Region name",
+					Computed:    true,
+				},
+				"description": schema.StringAttribute{
+					Description: "Region description",
+					Computed:    true,
+				},
+			},
+		},
+		"instance_type": schema.SingleNestedAttribute{
+			Description: "Instance type information",
+			Computed:    true,
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Description: "Instance type name",
+					Computed:    true,
+				},
+				"description": schema.StringAttribute{
+					Description: "Instance type description",
+					Computed:    true,
+				},
+				"gpu_description": schema.StringAttribute{
+					Description: "GPU description",
+					Computed:    true,
+				},
+				"price_cents_per_hour": schema.Int64Attribute{
+					Description: "Price in cents per hour",
+					Computed:    true,
+				},
+				"specs": schema.SingleNestedAttribute{
+					Description: "Instance specifications",
+					Computed:    true,
+					Attributes: map[string]schema.Attribute{
+						"vcpus": schema.Int64Attribute{
+							Description: "Number of virtual CPUs",
+							Computed:    true,
+						},
+						"memory_gib": schema.Int64Attribute{
+							Description: "Memory in GiB",
+							Computed:    true,
+						},
+						"storage_gib": schema.Int64Attribute{
+							Description: "Storage in GiB",
+							Computed:    true,
+						},
+						"gpus": schema.Int64Attribute{
+							Description: "Number of GPUs",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
@@ -2,14 +2,25 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/elct9620/terraform-provider-lambdalabs/pkg/lambdalabs"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	helper "github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
@@ -17,14 +28,32 @@ const (
 	InstanceStateBooting     string = "booting"
 	InstanceStateActive      string = "active"
 	InstanceStateContactable string = "contactable"
+	InstanceStateTerminated  string = "terminated"
+	InstanceStateTerminating string = "terminating"
+	InstanceStateUnhealthy   string = "unhealthy"
 )
 
+const (
+	InstanceCreateFailureTerminate string = "terminate"
+	InstanceCreateFailureKeep      string = "keep"
+)
+
+// instancePrivateImported marks an imported instance whose user data hash is unknown
+const instancePrivateImported = "imported"
+
 var (
-	_                            resource.Resource                = &instanceResource{}
-	_                            resource.ResourceWithConfigure   = &instanceResource{}
-	_                            resource.ResourceWithImportState = &instanceResource{}
-	defaultInstanceCreateTimeout                                  = 10 * time.Minute
-	instanceCreateDelay                                           = 10 * time.Second
+	_                            resource.Resource                   = &instanceResource{}
+	_                            resource.ResourceWithConfigure      = &instanceResource{}
+	_                            resource.ResourceWithImportState    = &instanceResource{}
+	_                            resource.ResourceWithValidateConfig = &instanceResource{}
+	_                            resource.ResourceWithModifyPlan     = &instanceResource{}
+	defaultInstanceCreateTimeout                                     = 10 * time.Minute
+	instanceCreateDelay                                              = 10 * time.Second
+	instanceBootWindow                                               = 5 * time.Minute
+	defaultInstanceDeleteTimeout                                     = 10 * time.Minute
+	instanceDeleteMinTimeout                                         = 5 * time.Second
+	instanceCapacityMinWait                                          = 5 * time.Second
+	instanceCapacityMaxWait                                          = 1 * time.Minute
 )
 
 type instanceResource struct {
@@ -32,14 +61,32 @@ type instanceResource struct {
 }
 
 type instanceModel struct {
-	ID               types.String   `tfsdk:"id"`
-	Name             types.String   `tfsdk:"name"`
-	IP               types.String   `tfsdk:"ip"`
-	RegionName       types.String   `tfsdk:"region_name"`
-	InstanceTypeName types.String   `tfsdk:"instance_type_name"`
-	SSHKeyNames      types.List     `tfsdk:"ssh_key_names"`
-	FileSystemNames  types.List     `tfsdk:"file_system_names"`
-	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+	ID                 types.String             `tfsdk:"id"`
+	Name               types.String             `tfsdk:"name"`
+	IP                 types.String             `tfsdk:"ip"`
+	PrivateIP          types.String             `tfsdk:"private_ip"`
+	Hostname           types.String             `tfsdk:"hostname"`
+	JupyterURL         types.String             `tfsdk:"jupyter_url"`
+	JupyterToken       types.String             `tfsdk:"jupyter_token"`
+	IsReserved         types.Bool               `tfsdk:"is_reserved"`
+	Status             types.String             `tfsdk:"status"`
+	RegionName         types.String             `tfsdk:"region_name"`
+	InstanceTypeName   types.String             `tfsdk:"instance_type_name"`
+	SSHKeyNames        types.List               `tfsdk:"ssh_key_names"`
+	FileSystemNames    types.List               `tfsdk:"file_system_names"`
+	UserData           types.String             `tfsdk:"user_data"`
+	UserDataHash       types.String             `tfsdk:"user_data_hash"`
+	Image              *instanceImageModel      `tfsdk:"image"`
+	Placement          []instancePlacementModel `tfsdk:"placement"`
+	WaitForCapacity    types.Bool               `tfsdk:"wait_for_capacity"`
+	OnCreateFailure    types.String             `tfsdk:"on_create_failure"`
+	DeletionProtection types.Bool               `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value           `tfsdk:"timeouts"`
+}
+
+type instancePlacementModel struct {
+	RegionName       types.String `tfsdk:"region_name"`
+	InstanceTypeName types.String `tfsdk:"instance_type_name"`
 }
 
 func NewInstanceResource() resource.Resource {
@@ -59,37 +106,133 @@ func (r *instanceResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The instance ID",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The instance name",
+				MarkdownDescription: "The instance name, changes are applied in place",
 				Optional:            true,
 			},
 			"ip": schema.StringAttribute{
 				MarkdownDescription: "The public IP address",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private_ip": schema.StringAttribute{
+				MarkdownDescription: "The private IP address",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				MarkdownDescription: "The hostname of the instance",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"jupyter_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the Jupyter notebook running on the instance",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"jupyter_token": schema.StringAttribute{
+				MarkdownDescription: "The token to log in the Jupyter notebook",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"is_reserved": schema.BoolAttribute{
+				MarkdownDescription: "Whether the instance is running on reserved capacity",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The instance status, e.g. `active` or `unhealthy`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"region_name": schema.StringAttribute{
-				MarkdownDescription: "The instance region name",
-				Required:            true,
+				MarkdownDescription: "The instance region name, conflicts with `placement` which records the launched region here",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"instance_type_name": schema.StringAttribute{
-				MarkdownDescription: "The instance type name",
-				Required:            true,
+				MarkdownDescription: "The instance type name, conflicts with `placement` which records the launched instance type here",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"ssh_key_names": schema.ListAttribute{
 				MarkdownDescription: "The SSH Key names to install into instance",
 				Required:            true,
 				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
 			},
 			"file_system_names": schema.ListAttribute{
 				MarkdownDescription: "Optional list of file system names to attach to the instance",
 				Optional:            true,
 				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_capacity": schema.BoolAttribute{
+				MarkdownDescription: "Keep retrying the launch when no capacity is available instead of failing immediately. " +
+					"The retries stop 5 minutes, or half of `timeouts.create` when shorter, before the create deadline to leave the instance time to boot",
+				Optional: true,
 			},
+			"on_create_failure": schema.StringAttribute{
+				MarkdownDescription: "What to do with a launched instance which fails to boot, `terminate` (default) stops the billing " +
+					"and `keep` saves it to the state as tainted for troubleshooting",
+				Optional: true,
+			},
+			"deletion_protection": deletionProtectionAttribute("instance"),
+			"user_data":           userDataAttribute(),
+			"user_data_hash":      userDataHashAttribute(),
 		},
 		Blocks: map[string]schema.Block{
+			"image": instanceImageBlock("instance"),
+			"placement": schema.ListNestedBlock{
+				MarkdownDescription: "Ordered placement candidates, the first one with available capacity is launched. " +
+					"Changes only force replacement when the launched placement is no longer a candidate",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"region_name": schema.StringAttribute{
+							MarkdownDescription: "The candidate region name",
+							Required:            true,
+						},
+						"instance_type_name": schema.StringAttribute{
+							MarkdownDescription: "The candidate instance type name",
+							Required:            true,
+						},
+					},
+				},
+			},
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
 				Create: true,
+				Delete: true,
 			}),
 		},
 	}
@@ -104,6 +247,103 @@ func (r *instanceResource) Configure(_ context.Context, req resource.ConfigureRe
 	r.client = req.ProviderData.(*lambdalabs.Client)
 }
 
+// ValidateConfig ensures the placement, user data and image selector can be launched.
+func (r *instanceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config instanceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateUserDataAttribute(&resp.Diagnostics, config.UserData)
+
+	if !config.OnCreateFailure.IsNull() && !config.OnCreateFailure.IsUnknown() {
+		switch config.OnCreateFailure.ValueString() {
+		case InstanceCreateFailureTerminate, InstanceCreateFailureKeep:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("on_create_failure"),
+				"Invalid Lambdalabs instance create failure action",
+				"The on_create_failure must be one of "+InstanceCreateFailureTerminate+" or "+InstanceCreateFailureKeep,
+			)
+		}
+	}
+
+	hasPlacement := len(config.Placement) > 0
+	if hasPlacement && (!config.RegionName.IsNull() || !config.InstanceTypeName.IsNull()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("placement"),
+			"Invalid Lambdalabs instance placement",
+			"The placement block conflicts with region_name and instance_type_name",
+		)
+	}
+
+	if !hasPlacement && (config.RegionName.IsNull() || config.InstanceTypeName.IsNull()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("region_name"),
+			"Invalid Lambdalabs instance placement",
+			"Both region_name and instance_type_name must be specified when no placement block is given",
+		)
+	}
+
+	validateInstanceImageSelector(&resp.Diagnostics, config.Image)
+}
+
+// ModifyPlan validates the planned launch options against the API before apply.
+func (r *instanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		var state instanceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		checkDeletionProtection(&resp.Diagnostics, state.DeletionProtection, "instance", state.ID.ValueString())
+		return
+	}
+
+	var plan, state instanceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	imported, diags := req.Private.GetKey(ctx, instancePrivateImported)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.UserDataHash = planUserDataHash(ctx, req, resp, state.UserDataHash, imported != nil)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Candidates are only tried at create time, the instance is kept while it still matches one of them
+	if !req.State.Raw.IsNull() && len(plan.Placement) > 0 && !placementContains(plan.Placement, state.RegionName, state.InstanceTypeName) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("placement"))
+	}
+
+	if !req.State.Raw.IsNull() && (len(resp.RequiresReplace) > 0 || instanceRequiresReplace(plan.launchOptions(), state.launchOptions())) {
+		checkDeletionProtection(&resp.Diagnostics, state.DeletionProtection, "instance", state.ID.ValueString())
+	}
+
+	if r.client == nil {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		if reflect.DeepEqual(plan.Image, state.Image) && reflect.DeepEqual(plan.placementCandidates(), state.placementCandidates()) {
+			return
+		}
+	}
+
+	validateInstanceImage(ctx, r.client, plan.Image, plan.placementCandidates(), &resp.Diagnostics)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *instanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var instance instanceModel
@@ -136,39 +376,54 @@ func (r *instanceResource) Create(ctx context.Context, req resource.CreateReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	deadline := time.Now().Add(createTimeout)
 
 	if !instance.Name.IsNull() {
 		name := instance.Name.ValueString()
 		apiReq.Name = &name
 	}
 
-	apiReq.InstanceTypeName = instance.InstanceTypeName.ValueString()
-	apiReq.RegionName = instance.RegionName.ValueString()
+	var userData types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("user_data"), &userData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	apiReq.UserData = userData.ValueString()
+
+	apiReq.Image = instance.Image.launchImage()
 
-	res, err := r.client.LaunchInstance(
-		ctx,
-		apiReq,
-	)
+	// Waiting for capacity stops early to leave the launched instance time to boot, otherwise it is terminated on timeout
+	launchDeadline := deadline.Add(-min(instanceBootWindow, createTimeout/2))
+	res, err := r.launchInstance(ctx, apiReq, &instance, launchDeadline, &resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating instance",
-			"Could not create instance, unexpected error: "+err.Error(),
+			"Could not create instance, unexpected error: "+errorDetail(err),
 		)
 		return
 	}
 
-	latestInstanceId := res.Data.IDs[0]
-	latestInstance, err := r.waitInstanceCreated(ctx, latestInstanceId, createTimeout)
-	if err != nil {
+	// Any 2xx response is accepted, an empty body decodes to a launch without instance IDs
+	if len(res.Data.IDs) == 0 {
 		resp.Diagnostics.AddError(
-			"Error creating instance",
-			"Could not create instance, unexpected error: "+err.Error(),
+			"Error launching instance",
+			"The launch request succeeded but the response contains no instance ID, check the Lambda Cloud dashboard for an untracked instance",
 		)
 		return
 	}
 
+	instance.RegionName = types.StringValue(apiReq.RegionName)
+	instance.InstanceTypeName = types.StringValue(apiReq.InstanceTypeName)
+
+	latestInstanceId := res.Data.IDs[0]
+	latestInstance, err := waitInstanceCreated(ctx, r.client, latestInstanceId, time.Until(deadline))
+	if err != nil {
+		r.handleCreateFailure(ctx, &instance, latestInstanceId, err, resp)
+		return
+	}
+
 	instance.ID = types.StringValue(latestInstance.ID)
-	instance.IP = types.StringValue(latestInstance.IP)
+	instance.setRuntimeDetails(latestInstance)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, instance)
@@ -190,36 +445,43 @@ func (r *instanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 	res, err := r.client.RetrieveInstance(ctx, &lambdalabs.RetrieveInstanceRequest{
 		Id: state.ID.ValueString(),
 	})
+	if errors.Is(err, lambdalabs.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error Reading Lambdalabs SSH Key",
-			"Could not find Lambdalabs SSH Key ID "+state.ID.ValueString()+": "+err.Error(),
+			"Error Reading Lambdalabs instance",
+			"Could not read Lambdalabs instance ID "+state.ID.ValueString()+": "+errorDetail(err),
 		)
 		return
 	}
 	latestInstance := res.Data
 
-	state.IP = types.StringValue(latestInstance.IP)
+	if latestInstance.Status == InstanceStateTerminated {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
-	// 確保在導入時設置這些屬性
-	if state.RegionName.IsNull() && latestInstance.Region.Name != "" {
+	state.setRuntimeDetails(&latestInstance)
+	state.Name = instanceNameValue(state.Name, latestInstance.Name)
+
+	if latestInstance.Region.Name != "" {
 		state.RegionName = types.StringValue(latestInstance.Region.Name)
 	}
 
-	if state.InstanceTypeName.IsNull() && latestInstance.InstanceType.Name != "" {
+	if latestInstance.InstanceType.Name != "" {
 		state.InstanceTypeName = types.StringValue(latestInstance.InstanceType.Name)
 	}
 
-	// 設置 SSH 密鑰名稱
-	if state.SSHKeyNames.IsNull() && len(latestInstance.SSHKeyNames) > 0 {
-		state.SSHKeyNames, diags = types.ListValueFrom(ctx, types.StringType, latestInstance.SSHKeyNames)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
+	state.SSHKeyNames, diags = instanceListValue(ctx, state.SSHKeyNames, latestInstance.SSHKeyNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	state.FileSystemNames, diags = types.ListValueFrom(ctx, types.StringType, latestInstance.FileSystemNames)
+	state.FileSystemNames, diags = instanceListValue(ctx, state.FileSystemNames, latestInstance.FileSystemNames)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -235,15 +497,73 @@ func (r *instanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *instanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Error Update Lambdalabs instance",
-		"Unsupported Method",
-	)
+	var plan, state instanceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.IP = state.IP
+	plan.PrivateIP = state.PrivateIP
+	plan.Hostname = state.Hostname
+	plan.JupyterURL = state.JupyterURL
+	plan.JupyterToken = state.JupyterToken
+	plan.IsReserved = state.IsReserved
+	plan.Status = state.Status
+
+	if !plan.Name.Equal(state.Name) {
+		_, err := r.client.UpdateInstance(ctx, &lambdalabs.UpdateInstanceRequest{
+			Id:   state.ID.ValueString(),
+			Name: plan.Name.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Update Lambdalabs instance",
+				"Could not rename instance ID "+state.ID.ValueString()+": "+errorDetail(err),
+			)
+			return
+		}
+	}
+
+	// The user data of an imported instance is only trusted until its hash is recorded
+	if !plan.UserDataHash.IsNull() {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, instancePrivateImported, nil)...)
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
-// ImportState imports the resource state from Terraform state.
+// ImportState imports the resource state by ID or name.
 func (r *instanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	defer func() {
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, instancePrivateImported, []byte("true"))...)
+		}
+	}()
+
+	importStateByName(ctx, req, resp, "instance", func(ctx context.Context) ([]importCandidate, error) {
+		res, err := r.client.ListInstances(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]importCandidate, 0, len(res.Data))
+		for _, instance := range res.Data {
+			if instance.Status == InstanceStateTerminated {
+				continue
+			}
+
+			candidates = append(candidates, importCandidate{ID: instance.ID, Name: instance.Name})
+		}
+
+		return candidates, nil
+	})
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -256,20 +576,202 @@ func (r *instanceResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	// Delete existing order
-	_, err := r.client.TerminateInstance(ctx, &lambdalabs.TerminateInstanceRequest{
-		Ids: []string{state.ID.ValueString()},
+	checkDeletionProtection(&resp.Diagnostics, state.DeletionProtection, "instance", state.ID.ValueString())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultInstanceDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	res, err := r.client.TerminateInstance(ctx, &lambdalabs.TerminateInstanceRequest{
+		Ids: []string{id},
 	})
+	if errors.Is(err, lambdalabs.ErrNotFound) {
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting instance",
-			"Could not delete instance, unexpected error: "+err.Error(),
+			"Could not delete instance, unexpected error: "+errorDetail(err),
 		)
 		return
 	}
+
+	if !slices.ContainsFunc(res.Data.TerminatedInstances, func(instance *lambdalabs.Instance) bool {
+		return instance != nil && instance.ID == id
+	}) {
+		resp.Diagnostics.AddError(
+			"Error Deleting instance",
+			"The API accepted the terminate request but did not confirm the termination of instance ID "+id+", check the instance in the Lambda Cloud dashboard",
+		)
+		return
+	}
+
+	// Attached file systems stay in use until the instance is fully terminated
+	if err := waitInstanceTerminated(ctx, r.client, id, deleteTimeout); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting instance",
+			"Could not wait for instance ID "+id+" to terminate, unexpected error: "+errorDetail(err),
+		)
+		return
+	}
+}
+
+// launchInstance retries the launch with backoff when wait_for_capacity is enabled,
+// the instance types are polled between attempts to avoid launching before capacity is reported again
+func (r *instanceResource) launchInstance(ctx context.Context, apiReq *lambdalabs.LaunchInstanceRequest, instance *instanceModel, deadline time.Time, diags *diag.Diagnostics) (*lambdalabs.LaunchInstanceResponse, error) {
+	wait := instanceCapacityMinWait
+	for attempt := 1; ; attempt++ {
+		res, err := r.launchOnce(ctx, apiReq, instance, diags)
+		if !instance.WaitForCapacity.ValueBool() || !errors.Is(err, lambdalabs.ErrInsufficientCapacity) {
+			return res, err
+		}
+
+		for {
+			if time.Now().Add(wait).After(deadline) {
+				return nil, fmt.Errorf("capacity did not become available in time to boot before the create timeout after %d attempts: %w", attempt, err)
+			}
+
+			tflog.Info(ctx, "Waiting for instance capacity", map[string]any{
+				"attempt": attempt,
+				"wait":    wait.String(),
+			})
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(wait):
+			}
+			wait = min(wait*2, instanceCapacityMaxWait)
+
+			available, err := r.hasCapacity(ctx, instance.placementCandidates())
+			if err != nil {
+				return nil, err
+			}
+
+			if available {
+				break
+			}
+
+			tflog.Debug(ctx, "No capacity reported for any placement candidate yet")
+		}
+	}
+}
+
+func (r *instanceResource) launchOnce(ctx context.Context, apiReq *lambdalabs.LaunchInstanceRequest, instance *instanceModel, diags *diag.Diagnostics) (*lambdalabs.LaunchInstanceResponse, error) {
+	if len(instance.Placement) > 0 {
+		return r.launchWithPlacement(ctx, apiReq, instance.Placement, diags)
+	}
+
+	apiReq.InstanceTypeName = instance.InstanceTypeName.ValueString()
+	apiReq.RegionName = instance.RegionName.ValueString()
+
+	return r.client.LaunchInstance(ctx, apiReq)
 }
 
-func (r *instanceResource) waitInstanceCreated(ctx context.Context, id string, createTimeout time.Duration) (*lambdalabs.Instance, error) {
+// launchWithPlacement tries the candidates in order and skips the ones without capacity,
+// the capacity reported by instance types may be stale so launch failures caused by capacity fall through as well
+func (r *instanceResource) launchWithPlacement(ctx context.Context, apiReq *lambdalabs.LaunchInstanceRequest, placement []instancePlacementModel, diags *diag.Diagnostics) (*lambdalabs.LaunchInstanceResponse, error) {
+	instanceTypes, err := r.client.ListInstanceTypes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list instance types to check capacity: %w", err)
+	}
+
+	var skipped []string
+	for _, candidate := range placement {
+		regionName := candidate.RegionName.ValueString()
+		instanceTypeName := candidate.InstanceTypeName.ValueString()
+
+		if reason := capacityUnavailable(instanceTypes.Data, regionName, instanceTypeName); reason != "" {
+			skipped = append(skipped, "- "+instanceTypeName+" in "+regionName+": "+reason)
+			continue
+		}
+
+		apiReq.RegionName = regionName
+		apiReq.InstanceTypeName = instanceTypeName
+
+		res, err := r.client.LaunchInstance(ctx, apiReq)
+		if errors.Is(err, lambdalabs.ErrInsufficientCapacity) {
+			skipped = append(skipped, "- "+instanceTypeName+" in "+regionName+": "+err.Error())
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("could not launch %s in %s: %w", instanceTypeName, regionName, err)
+		}
+
+		if len(skipped) > 0 {
+			diags.AddWarning(
+				"Instance launched with fallback placement",
+				"Launched "+instanceTypeName+" in "+regionName+" after skipping:\n"+strings.Join(skipped, "\n"),
+			)
+		}
+
+		return res, nil
+	}
+
+	return nil, &placementCapacityError{skipped: skipped}
+}
+
+func (r *instanceResource) hasCapacity(ctx context.Context, candidates []instancePlacementModel) (bool, error) {
+	instanceTypes, err := r.client.ListInstanceTypes(ctx)
+	if err != nil {
+		return false, fmt.Errorf("could not list instance types to check capacity: %w", err)
+	}
+
+	for _, candidate := range candidates {
+		if capacityUnavailable(instanceTypes.Data, candidate.RegionName.ValueString(), candidate.InstanceTypeName.ValueString()) == "" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// handleCreateFailure prevents the launched instance from running untracked when it never becomes ready
+func (r *instanceResource) handleCreateFailure(ctx context.Context, instance *instanceModel, id string, err error, resp *resource.CreateResponse) {
+	status := ""
+	var bootErr *instanceBootError
+	if errors.As(err, &bootErr) {
+		status = bootErr.Status
+		resp.Diagnostics.AddError(
+			"Instance "+bootErr.Status+" during boot",
+			"Instance ID "+id+" reported status "+bootErr.Status+" before becoming ready, "+
+				"check the instance logs in the Lambda Cloud dashboard or try another region or instance type",
+		)
+	} else {
+		resp.Diagnostics.AddError(
+			"Error creating instance",
+			"Could not create instance ID "+id+", unexpected error: "+errorDetail(err),
+		)
+	}
+
+	if instance.OnCreateFailure.ValueString() == InstanceCreateFailureKeep {
+		// Terraform taints the resource because of the error, the next apply replaces it
+		instance.ID = types.StringValue(id)
+		instance.setRuntimeDetails(&lambdalabs.Instance{Status: status})
+		resp.Diagnostics.Append(resp.State.Set(ctx, instance)...)
+		return
+	}
+
+	_, err = r.client.TerminateInstance(ctx, &lambdalabs.TerminateInstanceRequest{
+		Ids: []string{id},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error cleaning up instance",
+			"Could not terminate the failed instance ID "+id+", terminate it manually to stop the billing: "+errorDetail(err),
+		)
+	}
+}
+
+func waitInstanceCreated(ctx context.Context, client *lambdalabs.Client, id string, createTimeout time.Duration) (*lambdalabs.Instance, error) {
 	changeConfig := &helper.StateChangeConf{
 		Pending: []string{
 			InstanceStateBooting,
@@ -279,12 +781,18 @@ func (r *instanceResource) waitInstanceCreated(ctx context.Context, id string, c
 			InstanceStateContactable,
 		},
 		Refresh: func() (any, string, error) {
-			resp, err := r.client.RetrieveInstance(ctx, &lambdalabs.RetrieveInstanceRequest{
+			resp, err := client.RetrieveInstance(ctx, &lambdalabs.RetrieveInstanceRequest{
 				Id: id,
 			})
 			if err != nil {
 				return nil, "", err
 			}
+
+			switch resp.Data.Status {
+			case InstanceStateUnhealthy, InstanceStateTerminating, InstanceStateTerminated:
+				return nil, "", &instanceBootError{Status: resp.Data.Status}
+			}
+
 			return &resp.Data, resp.Data.Status, nil
 		},
 		Timeout: createTimeout,
@@ -298,3 +806,134 @@ func (r *instanceResource) waitInstanceCreated(ctx context.Context, id string, c
 
 	return nil, err
 }
+
+func waitInstanceTerminated(ctx context.Context, client *lambdalabs.Client, id string, deleteTimeout time.Duration) error {
+	changeConfig := &helper.StateChangeConf{
+		Pending: []string{
+			InstanceStateBooting,
+			InstanceStateActive,
+			InstanceStateContactable,
+			InstanceStateUnhealthy,
+			InstanceStateTerminating,
+		},
+		Target: []string{
+			InstanceStateTerminated,
+		},
+		Refresh: func() (any, string, error) {
+			resp, err := client.RetrieveInstance(ctx, &lambdalabs.RetrieveInstanceRequest{
+				Id: id,
+			})
+			if errors.Is(err, lambdalabs.ErrNotFound) {
+				return &lambdalabs.Instance{ID: id}, InstanceStateTerminated, nil
+			}
+
+			if err != nil {
+				return nil, "", err
+			}
+			return &resp.Data, resp.Data.Status, nil
+		},
+		Timeout:    deleteTimeout,
+		MinTimeout: instanceDeleteMinTimeout,
+	}
+	_, err := changeConfig.WaitForStateContext(ctx)
+
+	return err
+}
+
+// instanceBootError reports a status which never transitions to ready
+type instanceBootError struct {
+	Status string
+}
+
+func (e *instanceBootError) Error() string {
+	return "instance became " + e.Status + " during boot"
+}
+
+// placementCapacityError lists the skipped candidates and matches lambdalabs.ErrInsufficientCapacity
+type placementCapacityError struct {
+	skipped []string
+}
+
+func (e *placementCapacityError) Error() string {
+	return "no placement candidate has capacity available:\n" + strings.Join(e.skipped, "\n")
+}
+
+func (e *placementCapacityError) Is(target error) bool {
+	return target == lambdalabs.ErrInsufficientCapacity
+}
+
+func (m *instanceModel) launchOptions() instanceLaunchOptions {
+	return instanceLaunchOptions{
+		RegionName:       m.RegionName,
+		InstanceTypeName: m.InstanceTypeName,
+		SSHKeyNames:      m.SSHKeyNames,
+		FileSystemNames:  m.FileSystemNames,
+		Image:            m.Image,
+	}
+}
+
+func (m *instanceModel) placementCandidates() []instancePlacementModel {
+	if len(m.Placement) > 0 {
+		return m.Placement
+	}
+
+	return []instancePlacementModel{{RegionName: m.RegionName, InstanceTypeName: m.InstanceTypeName}}
+}
+
+func capacityUnavailable(instanceTypes map[string]lambdalabs.InstanceTypeInfo, regionName, instanceTypeName string) string {
+	info, ok := instanceTypes[instanceTypeName]
+	if !ok {
+		return "instance type is not offered"
+	}
+
+	for _, region := range info.RegionsWithCapacityAvailable {
+		if region.Name == regionName {
+			return ""
+		}
+	}
+
+	return "no capacity available in region"
+}
+
+func placementContains(placement []instancePlacementModel, regionName, instanceTypeName types.String) bool {
+	for _, candidate := range placement {
+		if candidate.RegionName.Equal(regionName) && candidate.InstanceTypeName.Equal(instanceTypeName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// instanceNameValue keeps an unnamed instance as the configured null or empty name, the API reports both as ""
+func instanceNameValue(current types.String, name string) types.String {
+	if name == "" && (current.IsNull() || current.ValueString() == "") {
+		return current
+	}
+
+	if name == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(name)
+}
+
+// instanceListValue keeps an unset list as null when the API reports it empty, to avoid replacing the instance for a nil to [] change
+func instanceListValue(ctx context.Context, current types.List, values []string) (types.List, diag.Diagnostics) {
+	if len(values) == 0 && (current.IsNull() || len(current.Elements()) == 0) {
+		return current, nil
+	}
+
+	return types.ListValueFrom(ctx, types.StringType, values)
+}
+
+// setRuntimeDetails copies the attributes which are only known after the instance is launched
+func (m *instanceModel) setRuntimeDetails(instance *lambdalabs.Instance) {
+	m.IP = types.StringValue(instance.IP)
+	m.PrivateIP = types.StringValue(instance.PrivateIP)
+	m.Hostname = types.StringValue(instance.Hostname)
+	m.JupyterURL = types.StringValue(instance.JupyterURL)
+	m.JupyterToken = types.StringValue(instance.JupyterToken)
+	m.IsReserved = types.BoolValue(instance.IsReserved)
+	m.Status = types.StringValue(instance.Status)
+}
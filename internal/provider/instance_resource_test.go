@@ -1,26 +1,73 @@
 package provider_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"strings"
+	"sync/atomic"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func Test_InstanceResource(t *testing.T) {
 	t.Parallel()
 
+	var terminated atomic.Bool
+	var name, instanceType atomic.Value
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch strings.TrimSpace(r.URL.Path) {
+		case "/instances":
+			resBody := fmt.Sprintf(`{ "data": [{ "id": "0920582c7ff041399e34823a0be62549", "name": %q, "status": "active" }] }`, name.Load())
+			w.Write([]byte(resBody)) //nolint:errcheck
 		case "/instances/0920582c7ff041399e34823a0be62549":
-			resBody := `
+			if r.Method == http.MethodPost {
+				var input struct {
+					Name string `json:"name"`
+				}
+
+				body, _ := io.ReadAll(r.Body)
+				json.Unmarshal(body, &input) //nolint:errcheck
+				name.Store(input.Name)
+
+				resBody := fmt.Sprintf(`
+				{
+					"data": {
+						"id": "0920582c7ff041399e34823a0be62549",
+						"name": %[1]q,
+						"ip": "10.10.10.1",
+						"status": "active"
+					}
+				}
+				`, input.Name)
+				w.Write([]byte(resBody)) //nolint:errcheck
+				return
+			}
+
+			if terminated.Load() {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{ "error": { "code": "global/object-does-not-exist", "message": "Specified instance does not exist." } }`)) //nolint:errcheck
+				return
+			}
+
+			resBody := fmt.Sprintf(`
 			{
 				"data": {
 					"id": "0920582c7ff041399e34823a0be62549",
-					"name": "",
+					"name": %[1]q,
 					"ip": "10.10.10.1",
+					"private_ip": "172.16.0.10",
+					"hostname": "172-16-0-10",
+					"jupyter_url": "https://jupyter-0920582c7ff041399e34823a0be62549.lambdaspaces.com/?token=secret",
+					"jupyter_token": "secret",
+					"is_reserved": false,
 					"status": "active",
 					"ssh_key_names": [
 						"terraform"
@@ -30,7 +77,7 @@ func Test_InstanceResource(t *testing.T) {
 						"description": "Austin, Texas"
 					},
 					"instance_type": {
-						"name": "gpu_1x_a100",
+						"name": %[2]q,
 						"description": "1x RTX A100 (24 GB)",
 						"price_cents_per_hour": 110,
 						"specs": {
@@ -41,9 +88,20 @@ func Test_InstanceResource(t *testing.T) {
 					}
 				}
 			}
-			`
+			`, name.Load(), instanceType.Load())
 			w.Write([]byte(resBody)) //nolint:errcheck
 		case "/instance-operations/launch":
+			terminated.Store(false)
+			var input struct {
+				Name             string `json:"name"`
+				InstanceTypeName string `json:"instance_type_name"`
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &input) //nolint:errcheck
+			name.Store(input.Name)
+			instanceType.Store(input.InstanceTypeName)
+
 			resBody := `
 			{
 				"data": {
@@ -55,6 +113,7 @@ func Test_InstanceResource(t *testing.T) {
 			`
 			w.Write([]byte(resBody)) //nolint:errcheck
 		case "/instance-operations/terminate":
+			terminated.Store(true)
 			resBody := `
 			{
 				"data": {
@@ -99,6 +158,12 @@ func Test_InstanceResource(t *testing.T) {
 					resource.TestCheckResourceAttr("lambdalabs_instance.default", "region_name", "us-tx-1"),
 					resource.TestCheckResourceAttr("lambdalabs_instance.default", "instance_type_name", "gpu_1x_a100"),
 					resource.TestCheckResourceAttr("lambdalabs_instance.default", "ssh_key_names.0", "terraform"),
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "private_ip", "172.16.0.10"),
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "hostname", "172-16-0-10"),
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "jupyter_url", "https://jupyter-0920582c7ff041399e34823a0be62549.lambdaspaces.com/?token=secret"),
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "jupyter_token", "secret"),
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "is_reserved", "false"),
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "status", "active"),
 					resource.TestCheckResourceAttr("lambdalabs_instance.default", "timeouts.create", "10s"),
 				),
 			},
@@ -107,6 +172,1164 @@ func Test_InstanceResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_instance" "default" {
+					name               = "training-node-1"
+					region_name        = "us-tx-1"
+					instance_type_name = "gpu_1x_a100"
+					ssh_key_names = [
+						"terraform"
+					]
+					timeouts {
+						create = "10s"
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "id", "0920582c7ff041399e34823a0be62549"),
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "name", "training-node-1"),
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "ip", "10.10.10.1"),
+				),
+			},
+			{
+				ResourceName:      "lambdalabs_instance.default",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "lambdalabs_instance.default",
+				ImportState:       true,
+				ImportStateId:     "name:training-node-1",
+				ImportStateVerify: true,
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_instance" "default" {
+					name               = "training-node-1"
+					region_name        = "us-tx-1"
+					instance_type_name = "gpu_1x_h100"
+					ssh_key_names = [
+						"terraform"
+					]
+					timeouts {
+						create = "10s"
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "id", "0920582c7ff041399e34823a0be62549"),
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "instance_type_name", "gpu_1x_h100"),
+				),
+			},
+		},
+	})
+}
+
+func Test_InstanceResource_Drift(t *testing.T) {
+	t.Parallel()
+
+	var terminated atomic.Bool
+	var name atomic.Value
+	var fileSystems atomic.Value
+	fileSystems.Store("[]")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSpace(r.URL.Path) {
+		case "/instances":
+			resBody := fmt.Sprintf(`{ "data": [{ "id": "0920582c7ff041399e34823a0be62549", "name": %q, "status": "active" }] }`, name.Load())
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/instances/0920582c7ff041399e34823a0be62549":
+			if r.Method == http.MethodPost {
+				var input struct {
+					Name string `json:"name"`
+				}
+
+				body, _ := io.ReadAll(r.Body)
+				json.Unmarshal(body, &input) //nolint:errcheck
+				name.Store(input.Name)
+
+				w.Write([]byte(`{ "data": { "id": "0920582c7ff041399e34823a0be62549", "status": "active" } }`)) //nolint:errcheck
+				return
+			}
+
+			if terminated.Load() {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{ "error": { "code": "global/object-does-not-exist", "message": "Specified instance does not exist." } }`)) //nolint:errcheck
+				return
+			}
+
+			resBody := fmt.Sprintf(`
+			{
+				"data": {
+					"id": "0920582c7ff041399e34823a0be62549",
+					"name": %q,
+					"ip": "10.10.10.1",
+					"status": "active",
+					"ssh_key_names": ["terraform"],
+					"file_system_names": %s,
+					"region": { "name": "us-tx-1" },
+					"instance_type": { "name": "gpu_1x_a100" }
+				}
+			}
+			`, name.Load(), fileSystems.Load())
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/instance-operations/launch":
+			terminated.Store(false)
+			var input struct {
+				Name string `json:"name"`
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &input) //nolint:errcheck
+			name.Store(input.Name)
+
+			w.Write([]byte(`{ "data": { "instance_ids": ["0920582c7ff041399e34823a0be62549"] } }`)) //nolint:errcheck
+		case "/instance-operations/terminate":
+			terminated.Store(true)
+			w.Write([]byte(`{ "data": { "terminated_instances": [{ "id": "0920582c7ff041399e34823a0be62549", "status": "terminated" }] } }`)) //nolint:errcheck
+		}
+	}))
+
+	config := func(name string) string {
+		return providerConfig(server.URL) + fmt.Sprintf(`
+		resource "lambdalabs_instance" "default" {
+			name               = %q
+			region_name        = "us-tx-1"
+			instance_type_name = "gpu_1x_a100"
+			ssh_key_names = [
+				"terraform"
+			]
+			timeouts {
+				create = "10s"
+			}
+		}
+		`, name)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "name", ""),
+					resource.TestCheckNoResourceAttr("lambdalabs_instance.default", "file_system_names"),
+				),
+			},
+			{
+				Config: config("training-node-1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "name", "training-node-1"),
+				),
+			},
+			{
+				PreConfig: func() {
+					name.Store("renamed-in-console")
+				},
+				Config:             config("training-node-1"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("training-node-1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "name", "training-node-1"),
+					func(_ *terraform.State) error {
+						if name.Load() != "training-node-1" {
+							return fmt.Errorf("expected the console rename to be reverted, got %v", name.Load())
+						}
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() {
+					fileSystems.Store(`["shared"]`)
+				},
+				Config:             config("training-node-1"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					fileSystems.Store("[]")
+				},
+				ResourceName:      "lambdalabs_instance.default",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "lambdalabs_instance.default",
+				ImportState:       true,
+				ImportStateId:     "training-node-1",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func Test_InstanceResource_Vanished(t *testing.T) {
+	t.Parallel()
+
+	var terminated atomic.Bool
+	var launches atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSpace(r.URL.Path) {
+		case "/instances/0920582c7ff041399e34823a0be62549":
+			if terminated.Load() {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{ "error": { "code": "global/object-does-not-exist", "message": "Specified instance does not exist." } }`)) //nolint:errcheck
+				return
+			}
+
+			resBody := `
+			{
+				"data": {
+					"id": "0920582c7ff041399e34823a0be62549",
+					"ip": "10.10.10.1",
+					"status": "active",
+					"ssh_key_names": ["terraform"],
+					"region": { "name": "us-tx-1" },
+					"instance_type": { "name": "gpu_1x_a100" }
+				}
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/instance-operations/launch":
+			launches.Add(1)
+			terminated.Store(false)
+			w.Write([]byte(`{ "data": { "instance_ids": ["0920582c7ff041399e34823a0be62549"] } }`)) //nolint:errcheck
+		case "/instance-operations/terminate":
+			terminated.Store(true)
+			w.Write([]byte(`{ "data": { "terminated_instances": [{ "id": "0920582c7ff041399e34823a0be62549", "status": "terminated" }] } }`)) //nolint:errcheck
+		}
+	}))
+
+	config := providerConfig(server.URL) + `
+	resource "lambdalabs_instance" "default" {
+		region_name        = "us-tx-1"
+		instance_type_name = "gpu_1x_a100"
+		ssh_key_names = [
+			"terraform"
+		]
+		timeouts {
+			create = "10s"
+		}
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "id", "0920582c7ff041399e34823a0be62549"),
+				),
+			},
+			{
+				PreConfig: func() {
+					terminated.Store(true)
+				},
+				Config: config,
+				Check: func(*terraform.State) error {
+					if launches.Load() != 2 {
+						return fmt.Errorf("expected instance to be launched again, got %d launches", launches.Load())
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func Test_InstanceResource_Image(t *testing.T) {
+	t.Parallel()
+
+	var terminated atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSpace(r.URL.Path) {
+		case "/images":
+			resBody := `
+			{
+				"data": [
+					{
+						"id": "43336648-096d-4cba-9aa2-f9bb7727639d",
+						"name": "lambda-stack-22.04",
+						"family": "lambda-stack-22-04",
+						"version": "22.04",
+						"architecture": "x86_64",
+						"region": { "name": "us-tx-1" }
+					},
+					{
+						"id": "5678abcd-096d-4cba-9aa2-f9bb7727639d",
+						"name": "lambda-stack-22.04-arm64",
+						"family": "lambda-stack-22-04",
+						"version": "22.04",
+						"architecture": "arm64",
+						"region": { "name": "us-east-3" }
+					}
+				]
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/instances/0920582c7ff041399e34823a0be62549":
+			if terminated.Load() {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{ "error": { "code": "global/object-does-not-exist", "message": "Specified instance does not exist." } }`)) //nolint:errcheck
+				return
+			}
+
+			resBody := `
+			{
+				"data": {
+					"id": "0920582c7ff041399e34823a0be62549",
+					"ip": "10.10.10.1",
+					"status": "active",
+					"ssh_key_names": ["terraform"],
+					"region": { "name": "us-tx-1" },
+					"instance_type": { "name": "gpu_1x_a100" }
+				}
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/instance-operations/launch":
+			terminated.Store(false)
+			var input struct {
+				Image struct {
+					ID string `json:"id"`
+				} `json:"image"`
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &input) //nolint:errcheck
+
+			if input.Image.ID != "43336648-096d-4cba-9aa2-f9bb7727639d" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{ "error": { "code": "global/invalid-parameters", "message": "Invalid image." } }`)) //nolint:errcheck
+				return
+			}
+
+			w.Write([]byte(`{ "data": { "instance_ids": ["0920582c7ff041399e34823a0be62549"] } }`)) //nolint:errcheck
+		case "/instance-operations/terminate":
+			terminated.Store(true)
+			w.Write([]byte(`{ "data": { "terminated_instances": [{ "id": "0920582c7ff041399e34823a0be62549", "status": "terminated" }] } }`)) //nolint:errcheck
+		}
+	}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_instance" "default" {
+					region_name        = "us-west-1"
+					instance_type_name = "gpu_1x_a100"
+					ssh_key_names = [
+						"terraform"
+					]
+					image {
+						family = "lambda-stack-22-04"
+					}
+				}
+				`,
+				ExpectError: regexp.MustCompile("No image with family lambda-stack-22-04 is available in region us-west-1"),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_instance" "default" {
+					ssh_key_names = [
+						"terraform"
+					]
+					image {
+						id = "43336648-096d-4cba-9aa2-f9bb7727639d"
+					}
+					placement {
+						region_name        = "us-tx-1"
+						instance_type_name = "gpu_1x_a100"
+					}
+					placement {
+						region_name        = "us-east-3"
+						instance_type_name = "gpu_1x_gh200"
+					}
+				}
+				`,
+				ExpectError: regexp.MustCompile("No image with id 43336648-096d-4cba-9aa2-f9bb7727639d is available in region us-east-3"),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_instance" "default" {
+					region_name        = "us-tx-1"
+					instance_type_name = "gpu_1x_a100"
+					ssh_key_names = [
+						"terraform"
+					]
+					image {
+						id     = "43336648-096d-4cba-9aa2-f9bb7727639d"
+						family = "lambda-stack-22-04"
+					}
+				}
+				`,
+				ExpectError: regexp.MustCompile("Exactly one of image id or family must be specified"),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_instance" "default" {
+					region_name        = "us-tx-1"
+					instance_type_name = "gpu_1x_a100"
+					ssh_key_names = [
+						"terraform"
+					]
+					image {
+						id = "43336648-096d-4cba-9aa2-f9bb7727639d"
+					}
+					timeouts {
+						create = "10s"
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "id", "0920582c7ff041399e34823a0be62549"),
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "image.id", "43336648-096d-4cba-9aa2-f9bb7727639d"),
+				),
+			},
+		},
+	})
+}
+
+func Test_InstanceResource_UserData(t *testing.T) {
+	t.Parallel()
+
+	var terminated atomic.Bool
+	var launchedUserData atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSpace(r.URL.Path) {
+		case "/instances":
+			w.Write([]byte(`{ "data": [{ "id": "0920582c7ff041399e34823a0be62549", "status": "active" }] }`)) //nolint:errcheck
+		case "/instances/0920582c7ff041399e34823a0be62549":
+			if terminated.Load() {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{ "error": { "code": "global/object-does-not-exist", "message": "Specified instance does not exist." } }`)) //nolint:errcheck
+				return
+			}
+
+			resBody := `
+			{
+				"data": {
+					"id": "0920582c7ff041399e34823a0be62549",
+					"ip": "10.10.10.1",
+					"status": "active",
+					"ssh_key_names": ["terraform"],
+					"region": { "name": "us-tx-1" },
+					"instance_type": { "name": "gpu_1x_a100" }
+				}
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/instance-operations/launch":
+			terminated.Store(false)
+			var input struct {
+				UserData string `json:"user_data"`
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &input) //nolint:errcheck
+			launchedUserData.Store(input.UserData)
+
+			w.Write([]byte(`{ "data": { "instance_ids": ["0920582c7ff041399e34823a0be62549"] } }`)) //nolint:errcheck
+		case "/instance-operations/terminate":
+			terminated.Store(true)
+			w.Write([]byte(`{ "data": { "terminated_instances": [{ "id": "0920582c7ff041399e34823a0be62549", "status": "terminated" }] } }`)) //nolint:errcheck
+		}
+	}))
+
+	config := providerConfig(server.URL) + `
+	resource "lambdalabs_instance" "default" {
+		region_name        = "us-tx-1"
+		instance_type_name = "gpu_1x_a100"
+		ssh_key_names = [
+			"terraform"
+		]
+		user_data = "#cloud-config\npackages:\n  - htop\n"
+		timeouts {
+			create = "10s"
+		}
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		// Write-only attributes are rejected before Terraform 1.11
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_instance" "default" {
+					region_name        = "us-tx-1"
+					instance_type_name = "gpu_1x_a100"
+					ssh_key_names = [
+						"terraform"
+					]
+					user_data = "apt-get install -y htop"
+				}
+				`,
+				ExpectError: regexp.MustCompile("user data must start with #cloud-config or a shell script"),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_instance" "default" {
+					region_name        = "us-tx-1"
+					instance_type_name = "gpu_1x_a100"
+					ssh_key_names = [
+						"terraform"
+					]
+					user_data = "#cloud-config\npackages: [htop"
+				}
+				`,
+				ExpectError: regexp.MustCompile("user data is not a valid #cloud-config YAML document"),
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("lambdalabs_instance.default", "user_data"),
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "user_data_hash", "c430bfdaec25e7af4042c26c30b3108fd40dca7a59554c765bcb0bfc62562cbd"),
+					func(_ *terraform.State) error {
+						if launchedUserData.Load() != "#cloud-config\npackages:\n  - htop\n" {
+							return fmt.Errorf("expected user data to be launched, got %q", launchedUserData.Load())
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:       "lambdalabs_instance.default",
+				ImportState:        true,
+				ImportStatePersist: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("lambdalabs_instance.default", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("lambdalabs_instance.default", "user_data_hash", "c430bfdaec25e7af4042c26c30b3108fd40dca7a59554c765bcb0bfc62562cbd"),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func Test_InstanceResource_Placement(t *testing.T) {
+	t.Parallel()
+
+	var terminated atomic.Bool
+	var launched atomic.Value
+	var launches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSpace(r.URL.Path) {
+		case "/instance-types":
+			resBody := `
+			{
+				"data": {
+					"gpu_1x_a100": {
+						"instance_type": { "name": "gpu_1x_a100" },
+						"regions_with_capacity_available": [{ "name": "us-east-1" }]
+					},
+					"gpu_1x_h100_pcie": {
+						"instance_type": { "name": "gpu_1x_h100_pcie" },
+						"regions_with_capacity_available": [{ "name": "us-west-1" }]
+					}
+				}
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/instances/0920582c7ff041399e34823a0be62549":
+			if terminated.Load() {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{ "error": { "code": "global/object-does-not-exist", "message": "Specified instance does not exist." } }`)) //nolint:errcheck
+				return
+			}
+
+			resBody := `
+			{
+				"data": {
+					"id": "0920582c7ff041399e34823a0be62549",
+					"ip": "10.10.10.1",
+					"status": "active",
+					"ssh_key_names": ["terraform"],
+					"region": { "name": "us-east-1" },
+					"instance_type": { "name": "gpu_1x_a100" }
+				}
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/instance-operations/launch":
+			terminated.Store(false)
+			var input struct {
+				RegionName       string `json:"region_name"`
+				InstanceTypeName string `json:"instance_type_name"`
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &input) //nolint:errcheck
+
+			if input.InstanceTypeName == "gpu_1x_h100_pcie" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{ "error": { "code": "instance-operations/launch/insufficient-capacity", "message": "Not enough capacity to fulfill launch request." } }`)) //nolint:errcheck
+				return
+			}
+
+			launched.Store(input.InstanceTypeName + "/" + input.RegionName)
+			launches.Add(1)
+			w.Write([]byte(`{ "data": { "instance_ids": ["0920582c7ff041399e34823a0be62549"] } }`)) //nolint:errcheck
+		case "/instance-operations/terminate":
+			terminated.Store(true)
+			w.Write([]byte(`{ "data": { "terminated_instances": [{ "id": "0920582c7ff041399e34823a0be62549", "status": "terminated" }] } }`)) //nolint:errcheck
+		}
+	}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_instance" "default" {
+					region_name = "us-tx-1"
+					ssh_key_names = [
+						"terraform"
+					]
+					placement {
+						region_name        = "us-east-1"
+						instance_type_name = "gpu_1x_a100"
+					}
+				}
+				`,
+				ExpectError: regexp.MustCompile("The placement block conflicts with region_name and instance_type_name"),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_instance" "default" {
+					ssh_key_names = [
+						"terraform"
+					]
+					placement {
+						region_name        = "us-tx-1"
+						instance_type_name = "gpu_1x_a100"
+					}
+					placement {
+						region_name        = "us-west-1"
+						instance_type_name = "gpu_8x_v100"
+					}
+				}
+				`,
+				ExpectError: regexp.MustCompile(`(?s)no placement candidate has capacity available.*gpu_1x_a100 in us-tx-1: no capacity available in region.*gpu_8x_v100 in us-west-1: instance type is not offered`),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_instance" "default" {
+					ssh_key_names = [
+						"terraform"
+					]
+					placement {
+						region_name        = "us-tx-1"
+						instance_type_name = "gpu_1x_a100"
+					}
+					placement {
+						region_name        = "us-west-1"
+						instance_type_name = "gpu_1x_h100_pcie"
+					}
+					placement {
+						region_name        = "us-east-1"
+						instance_type_name = "gpu_1x_a100"
+					}
+					timeouts {
+						create = "10s"
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "region_name", "us-east-1"),
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "instance_type_name", "gpu_1x_a100"),
+					func(_ *terraform.State) error {
+						if launched.Load() != "gpu_1x_a100/us-east-1" {
+							return fmt.Errorf("expected gpu_1x_a100 launched in us-east-1, got %v", launched.Load())
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_instance" "default" {
+					ssh_key_names = [
+						"terraform"
+					]
+					placement {
+						region_name        = "us-east-1"
+						instance_type_name = "gpu_1x_a100"
+					}
+					timeouts {
+						create = "10s"
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "region_name", "us-east-1"),
+					func(_ *terraform.State) error {
+						if launches.Load() != 1 {
+							return fmt.Errorf("expected the launched placement to be kept, got %d launches", launches.Load())
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func Test_InstanceResource_WaitForCapacity(t *testing.T) {
+	t.Parallel()
+
+	var terminated atomic.Bool
+	var launches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSpace(r.URL.Path) {
+		case "/instance-types":
+			resBody := `
+			{
+				"data": {
+					"gpu_8x_h100_sxm5": {
+						"instance_type": { "name": "gpu_8x_h100_sxm5" },
+						"regions_with_capacity_available": [{ "name": "us-tx-1" }]
+					}
+				}
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/instances/0920582c7ff041399e34823a0be62549":
+			if terminated.Load() {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{ "error": { "code": "global/object-does-not-exist", "message": "Specified instance does not exist." } }`)) //nolint:errcheck
+				return
+			}
+
+			resBody := `
+			{
+				"data": {
+					"id": "0920582c7ff041399e34823a0be62549",
+					"ip": "10.10.10.1",
+					"status": "active",
+					"ssh_key_names": ["terraform"],
+					"region": { "name": "us-tx-1" },
+					"instance_type": { "name": "gpu_8x_h100_sxm5" }
+				}
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/instance-operations/launch":
+			terminated.Store(false)
+			if launches.Add(1) == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{ "error": { "code": "instance-operations/launch/insufficient-capacity", "message": "Not enough capacity to fulfill launch request." } }`)) //nolint:errcheck
+				return
+			}
+
+			w.Write([]byte(`{ "data": { "instance_ids": ["0920582c7ff041399e34823a0be62549"] } }`)) //nolint:errcheck
+		case "/instance-operations/terminate":
+			terminated.Store(true)
+			w.Write([]byte(`{ "data": { "terminated_instances": [{ "id": "0920582c7ff041399e34823a0be62549", "status": "terminated" }] } }`)) //nolint:errcheck
+		}
+	}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_instance" "default" {
+					region_name        = "us-tx-1"
+					instance_type_name = "gpu_8x_h100_sxm5"
+					wait_for_capacity  = true
+					ssh_key_names = [
+						"terraform"
+					]
+					timeouts {
+						create = "1m"
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "id", "0920582c7ff041399e34823a0be62549"),
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "wait_for_capacity", "true"),
+					func(_ *terraform.State) error {
+						if launches.Load() != 2 {
+							return fmt.Errorf("expected launch to be retried once, got %d launches", launches.Load())
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func Test_InstanceResource_CreateFailure(t *testing.T) {
+	t.Parallel()
+
+	var healthy, terminated atomic.Bool
+	var launches, terminations atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSpace(r.URL.Path) {
+		case "/instances/0920582c7ff041399e34823a0be62549":
+			if terminated.Load() {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{ "error": { "code": "global/object-does-not-exist", "message": "Specified instance does not exist." } }`)) //nolint:errcheck
+				return
+			}
+
+			status := "unhealthy"
+			if healthy.Load() {
+				status = "active"
+			}
+
+			resBody := `
+			{
+				"data": {
+					"id": "0920582c7ff041399e34823a0be62549",
+					"ip": "10.10.10.1",
+					"status": "` + status + `",
+					"ssh_key_names": ["terraform"],
+					"region": { "name": "us-tx-1" },
+					"instance_type": { "name": "gpu_1x_a100" }
+				}
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/instance-operations/launch":
+			terminated.Store(false)
+			launches.Add(1)
+			w.Write([]byte(`{ "data": { "instance_ids": ["0920582c7ff041399e34823a0be62549"] } }`)) //nolint:errcheck
+		case "/instance-operations/terminate":
+			terminated.Store(true)
+			terminations.Add(1)
+			w.Write([]byte(`{ "data": { "terminated_instances": [{ "id": "0920582c7ff041399e34823a0be62549", "status": "terminated" }] } }`)) //nolint:errcheck
+		}
+	}))
+
+	config := providerConfig(server.URL) + `
+	resource "lambdalabs_instance" "default" {
+		region_name        = "us-tx-1"
+		instance_type_name = "gpu_1x_a100"
+		ssh_key_names = [
+			"terraform"
+		]
+		timeouts {
+			create = "10s"
+		}
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("Instance unhealthy during boot"),
+			},
+			{
+				PreConfig: func() {
+					healthy.Store(true)
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "id", "0920582c7ff041399e34823a0be62549"),
+					func(_ *terraform.State) error {
+						if launches.Load() != 2 || terminations.Load() != 1 {
+							return fmt.Errorf("expected the failed instance to be terminated, got %d launches and %d terminations", launches.Load(), terminations.Load())
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func Test_InstanceResource_CreateFailureKeep(t *testing.T) {
+	t.Parallel()
+
+	var terminated atomic.Bool
+	var terminations atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSpace(r.URL.Path) {
+		case "/instances/0920582c7ff041399e34823a0be62549":
+			if terminated.Load() {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{ "error": { "code": "global/object-does-not-exist", "message": "Specified instance does not exist." } }`)) //nolint:errcheck
+				return
+			}
+
+			resBody := `
+			{
+				"data": {
+					"id": "0920582c7ff041399e34823a0be62549",
+					"ip": "10.10.10.1",
+					"status": "unhealthy",
+					"ssh_key_names": ["terraform"],
+					"region": { "name": "us-tx-1" },
+					"instance_type": { "name": "gpu_1x_a100" }
+				}
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/instance-operations/launch":
+			terminated.Store(false)
+			w.Write([]byte(`{ "data": { "instance_ids": ["0920582c7ff041399e34823a0be62549"] } }`)) //nolint:errcheck
+		case "/instance-operations/terminate":
+			terminated.Store(true)
+			terminations.Add(1)
+			w.Write([]byte(`{ "data": { "terminated_instances": [{ "id": "0920582c7ff041399e34823a0be62549", "status": "terminated" }] } }`)) //nolint:errcheck
+		}
+	}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_instance" "default" {
+					region_name        = "us-tx-1"
+					instance_type_name = "gpu_1x_a100"
+					on_create_failure  = "keep"
+					ssh_key_names = [
+						"terraform"
+					]
+					timeouts {
+						create = "10s"
+					}
+				}
+				`,
+				ExpectError: regexp.MustCompile("Instance unhealthy during boot"),
+			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "id", "0920582c7ff041399e34823a0be62549"),
+					func(_ *terraform.State) error {
+						if terminations.Load() != 0 {
+							return fmt.Errorf("expected the failed instance to be kept, got %d terminations", terminations.Load())
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func Test_InstanceResource_Delete(t *testing.T) {
+	t.Parallel()
+
+	var terminateCalls, terminatingPolls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSpace(r.URL.Path) {
+		case "/instances/0920582c7ff041399e34823a0be62549":
+			status := "active"
+			if terminateCalls.Load() > 1 {
+				if terminatingPolls.Add(1) > 1 {
+					w.WriteHeader(http.StatusNotFound)
+					w.Write([]byte(`{ "error": { "code": "global/object-does-not-exist", "message": "Specified instance does not exist." } }`)) //nolint:errcheck
+					return
+				}
+				status = "terminating"
+			}
+
+			resBody := `
+			{
+				"data": {
+					"id": "0920582c7ff041399e34823a0be62549",
+					"ip": "10.10.10.1",
+					"status": "` + status + `",
+					"ssh_key_names": ["terraform"],
+					"region": { "name": "us-tx-1" },
+					"instance_type": { "name": "gpu_1x_a100" }
+				}
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/instance-operations/launch":
+			w.Write([]byte(`{ "data": { "instance_ids": ["0920582c7ff041399e34823a0be62549"] } }`)) //nolint:errcheck
+		case "/instance-operations/terminate":
+			if terminateCalls.Add(1) == 1 {
+				w.Write([]byte(`{ "data": { "terminated_instances": [] } }`)) //nolint:errcheck
+				return
+			}
+
+			w.Write([]byte(`{ "data": { "terminated_instances": [{ "id": "0920582c7ff041399e34823a0be62549", "status": "terminating" }] } }`)) //nolint:errcheck
+		}
+	}))
+
+	config := providerConfig(server.URL) + `
+	resource "lambdalabs_instance" "default" {
+		region_name        = "us-tx-1"
+		instance_type_name = "gpu_1x_a100"
+		ssh_key_names = [
+			"terraform"
+		]
+		timeouts {
+			create = "10s"
+			delete = "1m"
+		}
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy: func(_ *terraform.State) error {
+			if terminatingPolls.Load() < 2 {
+				return fmt.Errorf("expected delete to wait for termination, got %d polls", terminatingPolls.Load())
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "timeouts.delete", "1m"),
+				),
+			},
+			{
+				Config:      config,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("did not confirm the termination of instance ID"),
+			},
+		},
+	})
+}
+
+func Test_InstanceResource_DeletionProtection(t *testing.T) {
+	t.Parallel()
+
+	var terminated atomic.Bool
+	var terminations atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSpace(r.URL.Path) {
+		case "/instances/0920582c7ff041399e34823a0be62549":
+			if terminated.Load() {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{ "error": { "code": "global/object-does-not-exist", "message": "Specified instance does not exist." } }`)) //nolint:errcheck
+				return
+			}
+
+			resBody := `
+			{
+				"data": {
+					"id": "0920582c7ff041399e34823a0be62549",
+					"ip": "10.10.10.1",
+					"status": "active",
+					"ssh_key_names": ["terraform"],
+					"region": { "name": "us-tx-1" },
+					"instance_type": { "name": "gpu_1x_a100" }
+				}
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		case "/instance-operations/launch":
+			terminated.Store(false)
+			w.Write([]byte(`{ "data": { "instance_ids": ["0920582c7ff041399e34823a0be62549"] } }`)) //nolint:errcheck
+		case "/instance-operations/terminate":
+			terminated.Store(true)
+			terminations.Add(1)
+			w.Write([]byte(`{ "data": { "terminated_instances": [{ "id": "0920582c7ff041399e34823a0be62549", "status": "terminated" }] } }`)) //nolint:errcheck
+		}
+	}))
+
+	config := func(instanceType string, protected bool) string {
+		return providerConfig(server.URL) + fmt.Sprintf(`
+		resource "lambdalabs_instance" "default" {
+			region_name         = "us-tx-1"
+			instance_type_name  = %q
+			deletion_protection = %t
+			ssh_key_names = [
+				"terraform"
+			]
+			timeouts {
+				create = "10s"
+			}
+		}
+		`, instanceType, protected)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("gpu_1x_a100", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      config("gpu_1x_a100", true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("Deletion protection enabled"),
+			},
+			{
+				Config:      config("gpu_1x_h100", true),
+				ExpectError: regexp.MustCompile("Deletion protection enabled"),
+			},
+			{
+				Config: config("gpu_1x_a100", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("lambdalabs_instance.default", "deletion_protection", "false"),
+					func(_ *terraform.State) error {
+						if terminations.Load() != 0 {
+							return fmt.Errorf("expected protected instance to be kept, got %d terminations", terminations.Load())
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func Test_InstanceResource_EmptyLaunchResponse(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSpace(r.URL.Path) {
+		case "/instance-operations/launch":
+			w.Write([]byte(`{ "data": { "instance_ids": [] } }`)) //nolint:errcheck
+		default:
+			http.NotFoundHandler().ServeHTTP(w, r)
+		}
+	}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+				resource "lambdalabs_instance" "default" {
+					region_name        = "us-tx-1"
+					instance_type_name = "gpu_1x_a100"
+					ssh_key_names = [
+						"terraform"
+					]
+				}
+				`,
+				ExpectError: regexp.MustCompile("the response contains no instance ID"),
+			},
 		},
 	})
 }
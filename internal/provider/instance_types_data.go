@@ -136,7 +136,7 @@ func (d *instanceTypesData) Read(ctx context.Context, req datasource.ReadRequest
 
 	res, err := d.client.ListInstanceTypes(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to list instance types", err.Error())
+		resp.Diagnostics.AddError("failed to list instance types", errorDetail(err))
 		return
 	}
 
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"slices"
+
+	api "github.com/elct9620/terraform-provider-lambdalabs/pkg/lambdalabs"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &instancesData{}
+	_ datasource.DataSourceWithConfigure = &instancesData{}
+)
+
+type instancesData struct {
+	client *api.Client
+}
+
+func NewInstancesData() datasource.DataSource {
+	return &instancesData{}
+}
+
+func (d *instancesData) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instances"
+}
+
+func (d *instancesData) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Running Instances Data",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier",
+				Computed:    true,
+			},
+			"filter": schema.SingleNestedAttribute{
+				Description: "Filter the instances",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"region": schema.StringAttribute{
+						Description: "Filter by region name",
+						Optional:    true,
+					},
+					"instance_type": schema.StringAttribute{
+						Description: "Filter by instance type name",
+						Optional:    true,
+					},
+					"status": schema.StringAttribute{
+						Description: "Filter by instance status",
+						Optional:    true,
+					},
+					"name_regex": schema.StringAttribute{
+						Description: "Filter by a regular expression matching the instance name",
+						Optional:    true,
+					},
+					"file_system_name": schema.StringAttribute{
+						Description: "Filter by an attached file system name",
+						Optional:    true,
+					},
+				},
+			},
+			"instances": schema.ListNestedAttribute{
+				Description: "List of running instances",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: instanceDataAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *instancesData) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*api.Client)
+}
+
+func (d *instancesData) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model instancesDataModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if model.Filter != nil && !model.Filter.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(model.Filter.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("filter").AtName("name_regex"),
+				"invalid name regex",
+				errorDetail(err),
+			)
+			return
+		}
+	}
+
+	res, err := d.client.ListInstances(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to list instances", errorDetail(err))
+		return
+	}
+
+	// Apply filters if provided
+	filteredInstances := res.Data
+	if model.Filter != nil {
+		filteredInstances = []api.Instance{}
+		for _, instance := range res.Data {
+			if !model.Filter.Region.IsNull() && model.Filter.Region.ValueString() != "" {
+				if instance.Region.Name != model.Filter.Region.ValueString() {
+					continue
+				}
+			}
+
+			if !model.Filter.InstanceType.IsNull() && model.Filter.InstanceType.ValueString() != "" {
+				if instance.InstanceType.Name != model.Filter.InstanceType.ValueString() {
+					continue
+				}
+			}
+
+			if !model.Filter.Status.IsNull() && model.Filter.Status.ValueString() != "" {
+				if instance.Status != model.Filter.Status.ValueString() {
+					continue
+				}
+			}
+
+			if nameRegex != nil && !nameRegex.MatchString(instance.Name) {
+				continue
+			}
+
+			if !model.Filter.FileSystemName.IsNull() && model.Filter.FileSystemName.ValueString() != "" {
+				if !slices.Contains(instance.FileSystemNames, model.Filter.FileSystemName.ValueString()) {
+					continue
+				}
+			}
+
+			filteredInstances = append(filteredInstances, instance)
+		}
+	}
+
+	instances := make([]instanceDataModel, 0, len(filteredInstances))
+	for _, instance := range filteredInstances {
+		item, diags := newInstanceDataModel(ctx, instance)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		instances = append(instances, item)
+	}
+
+	model.ID = types.StringValue("instances")
+	model.Instances = instances
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
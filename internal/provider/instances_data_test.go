@@ -0,0 +1,152 @@
+package provider_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func Test_InstancesData(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/instances" {
+			resBody := `
+			{
+				"data": [
+					{
+						"id": "0920582c7ff041399e34823a0be62549",
+						"name": "training-node-1",
+						"ip": "10.10.10.1",
+						"status": "active",
+						"ssh_key_names": ["terraform"],
+						"file_system_names": ["shared-fs"],
+						"region": {
+							"name": "us-west-1",
+							"description": "California, USA"
+						},
+						"instance_type": {
+							"name": "gpu_1x_a100",
+							"description": "1x A100 (40 GB SXM4)",
+							"gpu_description": "A100 (40 GB SXM4)",
+							"price_cents_per_hour": 129,
+							"specs": {
+								"vcpus": 30,
+								"memory_gib": 200,
+								"storage_gib": 512,
+								"gpus": 1
+							}
+						}
+					},
+					{
+						"id": "1920582c7ff041399e34823a0be62549",
+						"name": "inference-node-1",
+						"ip": "10.10.10.2",
+						"status": "booting",
+						"ssh_key_names": ["terraform"],
+						"file_system_names": [],
+						"region": {
+							"name": "us-east-1",
+							"description": "Virginia, USA"
+						},
+						"instance_type": {
+							"name": "gpu_1x_a10",
+							"description": "1x A10 (24 GB PCIe)",
+							"gpu_description": "A10 (24 GB PCIe)",
+							"price_cents_per_hour": 75,
+							"specs": {
+								"vcpus": 30,
+								"memory_gib": 200,
+								"storage_gib": 1400,
+								"gpus": 1
+							}
+						}
+					}
+				]
+			}
+			`
+			w.Write([]byte(resBody)) //nolint:errcheck
+		}
+	}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+				data "lambdalabs_instances" "all" {}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.all", "id", "instances"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.all", "instances.#", "2"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.all", "instances.0.id", "0920582c7ff041399e34823a0be62549"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.all", "instances.0.name", "training-node-1"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.all", "instances.0.ip", "10.10.10.1"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.all", "instances.0.status", "active"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.all", "instances.0.ssh_key_names.0", "terraform"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.all", "instances.0.file_system_names.0", "shared-fs"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.all", "instances.0.region.name", "us-west-1"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.all", "instances.0.instance_type.name", "gpu_1x_a100"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.all", "instances.0.instance_type.specs.gpus", "1"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.all", "instances.1.id", "1920582c7ff041399e34823a0be62549"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.all", "instances.1.file_system_names.#", "0"),
+				),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				data "lambdalabs_instances" "filtered_by_region" {
+					filter = {
+						region = "us-east-1"
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.filtered_by_region", "instances.#", "1"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.filtered_by_region", "instances.0.name", "inference-node-1"),
+				),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				data "lambdalabs_instances" "filtered_by_status" {
+					filter = {
+						status        = "active"
+						instance_type = "gpu_1x_a100"
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.filtered_by_status", "instances.#", "1"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.filtered_by_status", "instances.0.name", "training-node-1"),
+				),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				data "lambdalabs_instances" "filtered_by_name" {
+					filter = {
+						name_regex = "^inference-"
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.filtered_by_name", "instances.#", "1"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.filtered_by_name", "instances.0.id", "1920582c7ff041399e34823a0be62549"),
+				),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				data "lambdalabs_instances" "filtered_by_file_system" {
+					filter = {
+						file_system_name = "shared-fs"
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.filtered_by_file_system", "instances.#", "1"),
+					resource.TestCheckResourceAttr("data.lambdalabs_instances.filtered_by_file_system", "instances.0.id", "0920582c7ff041399e34823a0be62549"),
+				),
+			},
+		},
+	})
+}
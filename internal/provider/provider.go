@@ -3,9 +3,11 @@ package provider
 import (
 	"context"
 	"os"
+	"time"
 
 	api "github.com/elct9620/terraform-provider-lambdalabs/pkg/lambdalabs"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -14,7 +16,8 @@ import (
 )
 
 var (
-	_ provider.Provider = &lambdalabsProvider{}
+	_ provider.Provider                       = &lambdalabsProvider{}
+	_ provider.ProviderWithEphemeralResources = &lambdalabsProvider{}
 )
 
 type lambdalabsProvider struct {
@@ -22,9 +25,19 @@ type lambdalabsProvider struct {
 }
 
 type lambdalabsProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
-	BaseUrl  types.String `tfsdk:"base_url"`
-	ApiKey   types.String `tfsdk:"api_key"`
+	Endpoint              types.String        `tfsdk:"endpoint"`
+	BaseUrl               types.String        `tfsdk:"base_url"`
+	ApiKey                types.String        `tfsdk:"api_key"`
+	RequestsPerSecond     types.Float64       `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int64         `tfsdk:"max_concurrent_requests"`
+	MaxConcurrentLaunches types.Int64         `tfsdk:"max_concurrent_launches"`
+	Retry                 *providerRetryModel `tfsdk:"retry"`
+}
+
+type providerRetryModel struct {
+	MaxAttempts types.Int64  `tfsdk:"max_attempts"`
+	MinWait     types.String `tfsdk:"min_wait"`
+	MaxWait     types.String `tfsdk:"max_wait"`
 }
 
 func New(version string) func() provider.Provider {
@@ -56,6 +69,37 @@ func (p *lambdalabsProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Limit the API request rate shared by all resources, unlimited by default",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Limit the number of in-flight API requests shared by all resources, unlimited by default",
+				Optional:            true,
+			},
+			"max_concurrent_launches": schema.Int64Attribute{
+				MarkdownDescription: "Limit the number of in-flight instance launches shared by all resources, `1` by default because the launch API is throttled aggressively, `0` is unlimited",
+				Optional:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"retry": schema.SingleNestedBlock{
+				MarkdownDescription: "Retry failed idempotent API requests with capped exponential backoff",
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						MarkdownDescription: "The total number of attempts per request, set to `1` to disable retry",
+						Optional:            true,
+					},
+					"min_wait": schema.StringAttribute{
+						MarkdownDescription: "The backoff before the first retry, e.g. `1s`, `0s` retries immediately",
+						Optional:            true,
+					},
+					"max_wait": schema.StringAttribute{
+						MarkdownDescription: "The maximum backoff between retries, e.g. `30s`. A longer `Retry-After` is not waited for and the error is returned",
+						Optional:            true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -137,10 +181,77 @@ func (p *lambdalabsProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
-	client := api.New(apiKey, api.WithBaseUrl(baseUrl))
+	retryPolicy := api.DefaultRetryPolicy
+	if config.Retry != nil {
+		if !config.Retry.MaxAttempts.IsNull() && !config.Retry.MaxAttempts.IsUnknown() {
+			retryPolicy.MaxAttempts = int(config.Retry.MaxAttempts.ValueInt64())
+		}
+
+		retryPolicy.MinWait = parseProviderDuration(resp, path.Root("retry").AtName("min_wait"), config.Retry.MinWait, retryPolicy.MinWait)
+		retryPolicy.MaxWait = parseProviderDuration(resp, path.Root("retry").AtName("max_wait"), config.Retry.MaxWait, retryPolicy.MaxWait)
+	}
+
+	if retryPolicy.MaxAttempts < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry").AtName("max_attempts"),
+			"Invalid Lambdalabs Retry Attempts",
+			"The retry max_attempts must be at least 1.",
+		)
+	}
+
+	if retryPolicy.MinWait > retryPolicy.MaxWait {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry").AtName("min_wait"),
+			"Invalid Lambdalabs Retry Wait",
+			"The retry min_wait must not be greater than max_wait.",
+		)
+	}
+
+	if config.RequestsPerSecond.ValueFloat64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Invalid Lambdalabs Request Rate",
+			"The requests_per_second must not be negative.",
+		)
+	}
+
+	if config.MaxConcurrentRequests.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid Lambdalabs Concurrent Requests",
+			"The max_concurrent_requests must not be negative.",
+		)
+	}
+
+	if config.MaxConcurrentLaunches.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_launches"),
+			"Invalid Lambdalabs Concurrent Launches",
+			"The max_concurrent_launches must not be negative.",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxConcurrentLaunches := api.DefaultMaxConcurrentLaunches
+	if !config.MaxConcurrentLaunches.IsNull() && !config.MaxConcurrentLaunches.IsUnknown() {
+		maxConcurrentLaunches = int(config.MaxConcurrentLaunches.ValueInt64())
+	}
+
+	client := api.New(
+		apiKey,
+		api.WithBaseUrl(baseUrl),
+		api.WithRetry(retryPolicy),
+		api.WithRequestsPerSecond(config.RequestsPerSecond.ValueFloat64()),
+		api.WithMaxConcurrentRequests(int(config.MaxConcurrentRequests.ValueInt64())),
+		api.WithMaxConcurrentLaunches(maxConcurrentLaunches),
+	)
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
 }
 
 func (p *lambdalabsProvider) DataSources(_ context.Context) []func() datasource.DataSource {
@@ -150,6 +261,8 @@ func (p *lambdalabsProvider) DataSources(_ context.Context) []func() datasource.
 		NewImageData,
 		NewFilesystemData,
 		NewFirewallData,
+		NewInstancesData,
+		NewInstanceData,
 	}
 }
 
@@ -158,5 +271,31 @@ func (p *lambdalabsProvider) Resources(_ context.Context) []func() resource.Reso
 		NewSshKeyResource,
 		NewInstanceResource,
 		NewFilesystemResource,
+		NewInstanceGroupResource,
+		NewFirewallRulesResource,
+	}
+}
+
+func (p *lambdalabsProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewSshKeyEphemeral,
+	}
+}
+
+func parseProviderDuration(resp *provider.ConfigureResponse, attrPath path.Path, value types.String, fallback time.Duration) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil || duration < 0 {
+		resp.Diagnostics.AddAttributeError(
+			attrPath,
+			"Invalid Duration",
+			"The value "+value.String()+" cannot be parsed as a non-negative duration, use a value like \"30s\" or \"2m\".",
+		)
+		return fallback
 	}
+
+	return duration
 }
@@ -2,10 +2,15 @@ package provider_test
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
 
 	"github.com/elct9620/terraform-provider-lambdalabs/internal/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 var testProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
@@ -20,3 +25,46 @@ func providerConfig(baseUrl string) string {
 	}
 	`, baseUrl)
 }
+
+func Test_ProviderClientOptions(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.Write([]byte(`{ "data": [] }`)) //nolint:errcheck
+	}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				provider "lambdalabs" {
+					base_url = %[1]q
+					api_key  = "test"
+
+					requests_per_second     = 10
+					max_concurrent_requests = 2
+					max_concurrent_launches = 4
+
+					retry {
+						max_attempts = 3
+						min_wait     = "10ms"
+						max_wait     = "100ms"
+					}
+				}
+
+				data "lambdalabs_images" "all" {}
+				`, server.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.lambdalabs_images.all", "images.#", "0"),
+				),
+			},
+		},
+	})
+}
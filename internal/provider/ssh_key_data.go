@@ -19,9 +19,11 @@ type sshKeyData struct {
 }
 
 type sshKeyDataModel struct {
-	Id        types.String `tfsdk:"id"`
-	Name      types.String `tfsdk:"name"`
-	PublicKey types.String `tfsdk:"public_key"`
+	Id                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	PublicKey         types.String `tfsdk:"public_key"`
+	FingerprintSHA256 types.String `tfsdk:"fingerprint_sha256"`
+	FingerprintMD5    types.String `tfsdk:"fingerprint_md5"`
 }
 
 func NewSshKeyData() datasource.DataSource {
@@ -48,6 +50,14 @@ func (d *sshKeyData) Schema(ctx context.Context, req datasource.SchemaRequest, r
 				Description: "The public key to install into instance",
 				Computed:    true,
 			},
+			"fingerprint_sha256": schema.StringAttribute{
+				Description: "The SHA256 fingerprint of the public key",
+				Computed:    true,
+			},
+			"fingerprint_md5": schema.StringAttribute{
+				Description: "The legacy MD5 fingerprint of the public key",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -69,7 +79,7 @@ func (d *sshKeyData) Read(ctx context.Context, req datasource.ReadRequest, resp
 
 	res, err := d.client.ListSshKeys(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("failed to list ssh keys", err.Error())
+		resp.Diagnostics.AddError("failed to list ssh keys", errorDetail(err))
 		return
 	}
 
@@ -79,6 +89,7 @@ func (d *sshKeyData) Read(ctx context.Context, req datasource.ReadRequest, resp
 		if key.Name == keyName {
 			model.Id = types.StringValue(key.Id)
 			model.PublicKey = types.StringValue(key.PublicKey)
+			model.FingerprintSHA256, model.FingerprintMD5 = sshKeyFingerprints(key.PublicKey)
 			resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 			return
 		}
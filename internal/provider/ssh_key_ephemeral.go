@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource = &sshKeyEphemeral{}
+)
+
+type sshKeyEphemeral struct{}
+
+type sshKeyEphemeralModel struct {
+	Algorithm         types.String `tfsdk:"algorithm"`
+	RSABits           types.Int64  `tfsdk:"rsa_bits"`
+	PublicKey         types.String `tfsdk:"public_key"`
+	PrivateKey        types.String `tfsdk:"private_key"`
+	FingerprintSHA256 types.String `tfsdk:"fingerprint_sha256"`
+	FingerprintMD5    types.String `tfsdk:"fingerprint_md5"`
+}
+
+func NewSshKeyEphemeral() ephemeral.EphemeralResource {
+	return &sshKeyEphemeral{}
+}
+
+// Metadata returns the ephemeral resource type name.
+func (e *sshKeyEphemeral) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ssh_key"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (e *sshKeyEphemeral) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generate an SSH key pair locally without storing the private key in the state. " +
+			"A new key pair is generated on every Terraform run and it is never registered with Lambdalabs, " +
+			"use it for write-only arguments, provider configurations or provisioner connections within the same run. " +
+			"Use the `lambdalabs_ssh_key` resource to register a key which can be installed into instances",
+		Attributes: map[string]schema.Attribute{
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "The key algorithm, `ed25519` (default) or `rsa`",
+				Optional:            true,
+			},
+			"rsa_bits": schema.Int64Attribute{
+				MarkdownDescription: "The RSA key size when `algorithm` is `rsa`, defaults to " + strconv.Itoa(defaultSSHKeyRSABits),
+				Optional:            true,
+			},
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "The generated public key in authorized_keys format",
+				Computed:            true,
+			},
+			"private_key": schema.StringAttribute{
+				MarkdownDescription: "The generated private key in OpenSSH PEM format",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"fingerprint_sha256": schema.StringAttribute{
+				MarkdownDescription: "The SHA256 fingerprint of the public key",
+				Computed:            true,
+			},
+			"fingerprint_md5": schema.StringAttribute{
+				MarkdownDescription: "The legacy MD5 fingerprint of the public key",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Open generates the key pair and returns the private key to Terraform.
+func (e *sshKeyEphemeral) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var key sshKeyEphemeralModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &key)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	algorithm := SSHKeyAlgorithmED25519
+	if !key.Algorithm.IsNull() {
+		algorithm = key.Algorithm.ValueString()
+	}
+
+	if algorithm != SSHKeyAlgorithmED25519 && algorithm != SSHKeyAlgorithmRSA {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("algorithm"),
+			"Invalid Lambdalabs SSH Key algorithm",
+			"The algorithm must be one of "+SSHKeyAlgorithmED25519+" or "+SSHKeyAlgorithmRSA,
+		)
+		return
+	}
+
+	rsaBits := defaultSSHKeyRSABits
+	if !key.RSABits.IsNull() {
+		if algorithm != SSHKeyAlgorithmRSA {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rsa_bits"),
+				"Invalid Lambdalabs SSH Key RSA bits",
+				"The rsa_bits can only be used when algorithm is "+SSHKeyAlgorithmRSA,
+			)
+			return
+		}
+
+		rsaBits = int(key.RSABits.ValueInt64())
+		if rsaBits < minSSHKeyRSABits || rsaBits > maxSSHKeyRSABits {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rsa_bits"),
+				"Invalid Lambdalabs SSH Key RSA bits",
+				"The rsa_bits must be between "+strconv.Itoa(minSSHKeyRSABits)+" and "+strconv.Itoa(maxSSHKeyRSABits),
+			)
+			return
+		}
+	}
+
+	publicKey, privateKey, err := generateSSHKeyPair(algorithm, rsaBits)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error generating SSH Key",
+			"Could not generate the SSH key pair, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	key.Algorithm = types.StringValue(algorithm)
+	key.PublicKey = types.StringValue(publicKey)
+	key.PrivateKey = types.StringValue(privateKey)
+	key.FingerprintSHA256, key.FingerprintMD5 = sshKeyFingerprints(publicKey)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &key)...)
+}
@@ -0,0 +1,55 @@
+package provider_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func Test_SSHKeyEphemeral(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.NotFoundHandler().ServeHTTP(w, r)
+	}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+				ephemeral "lambdalabs_ssh_key" "temporary" {
+					algorithm = "rsa"
+					rsa_bits  = 2048
+				}
+				`,
+				Check: func(_ *terraform.State) error {
+					if requests.Load() != 0 {
+						return fmt.Errorf("expected the key to be generated locally, got %d API requests", requests.Load())
+					}
+					return nil
+				},
+			},
+			{
+				Config: providerConfig(server.URL) + `
+				ephemeral "lambdalabs_ssh_key" "temporary" {
+					rsa_bits = 2048
+				}
+				`,
+				ExpectError: regexp.MustCompile(`The rsa_bits can only be used when algorithm is rsa`),
+			},
+		},
+	})
+}
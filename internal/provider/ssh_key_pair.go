@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	SSHKeyAlgorithmED25519 string = "ed25519"
+	SSHKeyAlgorithmRSA     string = "rsa"
+)
+
+const (
+	defaultSSHKeyRSABits = 4096
+	minSSHKeyRSABits     = 2048
+	maxSSHKeyRSABits     = 16384
+)
+
+// generateSSHKeyPair returns the public key in authorized_keys format and the private key in OpenSSH PEM format
+func generateSSHKeyPair(algorithm string, rsaBits int) (string, string, error) {
+	var private crypto.PrivateKey
+	var public crypto.PublicKey
+
+	switch algorithm {
+	case SSHKeyAlgorithmED25519:
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return "", "", err
+		}
+		private, public = priv, pub
+	case SSHKeyAlgorithmRSA:
+		priv, err := rsa.GenerateKey(rand.Reader, rsaBits)
+		if err != nil {
+			return "", "", err
+		}
+		private, public = priv, &priv.PublicKey
+	default:
+		return "", "", fmt.Errorf("unsupported SSH key algorithm %q", algorithm)
+	}
+
+	sshPublic, err := ssh.NewPublicKey(public)
+	if err != nil {
+		return "", "", err
+	}
+
+	block, err := ssh.MarshalPrivateKey(private, "")
+	if err != nil {
+		return "", "", err
+	}
+
+	publicKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPublic)))
+	return publicKey, string(pem.EncodeToMemory(block)), nil
+}
+
+// sshKeyFingerprints leaves the fingerprints empty for keys which cannot be parsed rather than failing the refresh
+func sshKeyFingerprints(publicKey string) (types.String, types.String) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return types.StringValue(""), types.StringValue("")
+	}
+
+	return types.StringValue(ssh.FingerprintSHA256(key)), types.StringValue(ssh.FingerprintLegacyMD5(key))
+}
@@ -2,18 +2,23 @@ package provider
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/elct9620/terraform-provider-lambdalabs/pkg/lambdalabs"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ resource.Resource                = &sshKeyResource{}
-	_ resource.ResourceWithConfigure   = &sshKeyResource{}
-	_ resource.ResourceWithImportState = &sshKeyResource{}
+	_ resource.Resource                   = &sshKeyResource{}
+	_ resource.ResourceWithConfigure      = &sshKeyResource{}
+	_ resource.ResourceWithImportState    = &sshKeyResource{}
+	_ resource.ResourceWithValidateConfig = &sshKeyResource{}
 )
 
 type sshKeyResource struct {
@@ -21,10 +26,14 @@ type sshKeyResource struct {
 }
 
 type sshKeyModel struct {
-	ID         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	PublicKey  types.String `tfsdk:"public_key"`
-	PrivateKey types.String `tfsdk:"private_key"`
+	ID                types.String      `tfsdk:"id"`
+	Name              types.String      `tfsdk:"name"`
+	PublicKey         sshPublicKeyValue `tfsdk:"public_key"`
+	PrivateKey        types.String      `tfsdk:"private_key"`
+	Algorithm         types.String      `tfsdk:"algorithm"`
+	RSABits           types.Int64       `tfsdk:"rsa_bits"`
+	FingerprintSHA256 types.String      `tfsdk:"fingerprint_sha256"`
+	FingerprintMD5    types.String      `tfsdk:"fingerprint_md5"`
 }
 
 func NewSshKeyResource() resource.Resource {
@@ -44,20 +53,64 @@ func (r *sshKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 			"id": schema.StringAttribute{
 				MarkdownDescription: "SSH Key ID",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "The SSH Key name",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"public_key": schema.StringAttribute{
-				MarkdownDescription: "The public key to install into instance",
+				MarkdownDescription: "The public key to install into instance, keys with the same key material are treated as equal",
+				CustomType:          sshPublicKeyType{},
 				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					sshPublicKeyUseStateWhenEqual{},
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"private_key": schema.StringAttribute{
-				MarkdownDescription: "If public key not given the Lambdalabs will generated one and return in this field",
+				MarkdownDescription: "If public key not given the Lambdalabs will generated one and return in this field, it is only returned once when the key is created. " +
+					"The key is in OpenSSH PEM format when generated locally by `algorithm`",
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Generate the key pair locally with `ed25519` or `rsa` and only upload the public key, conflicts with `public_key`",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rsa_bits": schema.Int64Attribute{
+				MarkdownDescription: "The RSA key size when `algorithm` is `rsa`, defaults to " + strconv.Itoa(defaultSSHKeyRSABits),
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"fingerprint_sha256": schema.StringAttribute{
+				MarkdownDescription: "The SHA256 fingerprint of the public key, e.g. `SHA256:...`",
 				Computed:            true,
-				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fingerprint_md5": schema.StringAttribute{
+				MarkdownDescription: "The legacy MD5 fingerprint of the public key, e.g. `aa:bb:...`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
@@ -72,6 +125,53 @@ func (r *sshKeyResource) Configure(_ context.Context, req resource.ConfigureRequ
 	r.client = req.ProviderData.(*lambdalabs.Client)
 }
 
+// ValidateConfig ensures the local key generation options are consistent.
+func (r *sshKeyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var key sshKeyModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &key)...)
+	if resp.Diagnostics.HasError() || key.Algorithm.IsUnknown() {
+		return
+	}
+
+	algorithm := key.Algorithm.ValueString()
+	switch {
+	case key.Algorithm.IsNull():
+	case algorithm != SSHKeyAlgorithmED25519 && algorithm != SSHKeyAlgorithmRSA:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("algorithm"),
+			"Invalid Lambdalabs SSH Key algorithm",
+			"The algorithm must be one of "+SSHKeyAlgorithmED25519+" or "+SSHKeyAlgorithmRSA,
+		)
+	case !key.PublicKey.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("algorithm"),
+			"Invalid Lambdalabs SSH Key algorithm",
+			"The algorithm conflicts with public_key, the public key is generated with the private key",
+		)
+	}
+
+	if key.RSABits.IsNull() || key.RSABits.IsUnknown() {
+		return
+	}
+
+	if algorithm != SSHKeyAlgorithmRSA {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rsa_bits"),
+			"Invalid Lambdalabs SSH Key RSA bits",
+			"The rsa_bits can only be used when algorithm is "+SSHKeyAlgorithmRSA,
+		)
+		return
+	}
+
+	if bits := key.RSABits.ValueInt64(); bits < minSSHKeyRSABits || bits > maxSSHKeyRSABits {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rsa_bits"),
+			"Invalid Lambdalabs SSH Key RSA bits",
+			"The rsa_bits must be between "+strconv.Itoa(minSSHKeyRSABits)+" and "+strconv.Itoa(maxSSHKeyRSABits),
+		)
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *sshKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var key sshKeyModel
@@ -91,19 +191,43 @@ func (r *sshKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 		}
 	}
 
+	var privateKey string
+	if !key.Algorithm.IsNull() {
+		rsaBits := defaultSSHKeyRSABits
+		if !key.RSABits.IsNull() {
+			rsaBits = int(key.RSABits.ValueInt64())
+		}
+
+		pubKey, privKey, err := generateSSHKeyPair(key.Algorithm.ValueString(), rsaBits)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating SSH Key",
+				"Could not generate SSH Key pair, unexpected error: "+err.Error(),
+			)
+			return
+		}
+
+		payload.PublicKey = &pubKey
+		privateKey = privKey
+	}
+
 	res, err := r.client.CreateSshKey(ctx, &payload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating SSH Key",
-			"Could not create SSH Key, unexpected error: "+err.Error(),
+			"Could not create SSH Key, unexpected error: "+errorDetail(err),
 		)
 		return
 	}
 
 	key.ID = types.StringValue(res.Data.Id)
 	key.Name = types.StringValue(res.Data.Name)
-	key.PublicKey = types.StringValue(res.Data.PublicKey)
-	key.PrivateKey = types.StringValue("")
+	key.PublicKey = newSSHPublicKeyValue(res.Data.PublicKey)
+	key.PrivateKey = types.StringValue(res.Data.PrivateKey)
+	if privateKey != "" {
+		key.PrivateKey = types.StringValue(privateKey)
+	}
+	key.FingerprintSHA256, key.FingerprintMD5 = sshKeyFingerprints(res.Data.PublicKey)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, key)
@@ -126,7 +250,7 @@ func (r *sshKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Lambdalabs SSH Key",
-			"Could not list Lambdalabs SSH Keys: "+err.Error(),
+			"Could not list Lambdalabs SSH Keys: "+errorDetail(err),
 		)
 		return
 	}
@@ -141,17 +265,19 @@ func (r *sshKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	if key == nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Lambdalabs SSH Key",
-			"Could not find Lambdalabs SSH Key ID "+keyId,
-		)
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
 	state.ID = types.StringValue(key.Id)
 	state.Name = types.StringValue(key.Name)
-	state.PublicKey = types.StringValue(key.PublicKey)
-	state.PrivateKey = types.StringValue(key.PrivateKey)
+	state.PublicKey = newSSHPublicKeyValue(key.PublicKey)
+	state.FingerprintSHA256, state.FingerprintMD5 = sshKeyFingerprints(key.PublicKey)
+
+	// The list endpoint never returns the private key which is generated at create time
+	if state.PrivateKey.IsNull() {
+		state.PrivateKey = types.StringValue(key.PrivateKey)
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -184,13 +310,25 @@ func (r *sshKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Delete Lambdalabs SSH Key",
-			"Could not delete Lambdalabs SSH Key ID "+state.ID.ValueString()+": "+err.Error(),
+			"Could not delete Lambdalabs SSH Key ID "+state.ID.ValueString()+": "+errorDetail(err),
 		)
 		return
 	}
 }
 
-// ImportState imports the resource state from Terraform state.
+// ImportState imports the resource state by ID or name.
 func (r *sshKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByName(ctx, req, resp, "SSH Key", func(ctx context.Context) ([]importCandidate, error) {
+		res, err := r.client.ListSshKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]importCandidate, 0, len(res.Data))
+		for _, key := range res.Data {
+			candidates = append(candidates, importCandidate{ID: key.Id, Name: key.Name})
+		}
+
+		return candidates, nil
+	})
 }
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	_ basetypes.StringTypable                    = sshPublicKeyType{}
+	_ basetypes.StringValuableWithSemanticEquals = sshPublicKeyValue{}
+	_ planmodifier.String                        = sshPublicKeyUseStateWhenEqual{}
+)
+
+// sshPublicKeyType compares public keys by key material, the API may change the whitespace or drop the comment
+type sshPublicKeyType struct {
+	basetypes.StringType
+}
+
+func (t sshPublicKeyType) Equal(o attr.Type) bool {
+	other, ok := o.(sshPublicKeyType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t sshPublicKeyType) String() string {
+	return "sshPublicKeyType"
+}
+
+func (t sshPublicKeyType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return sshPublicKeyValue{StringValue: in}, nil
+}
+
+func (t sshPublicKeyType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return sshPublicKeyValue{StringValue: stringValue}, nil
+}
+
+func (t sshPublicKeyType) ValueType(_ context.Context) attr.Value {
+	return sshPublicKeyValue{}
+}
+
+type sshPublicKeyValue struct {
+	basetypes.StringValue
+}
+
+func newSSHPublicKeyValue(publicKey string) sshPublicKeyValue {
+	return sshPublicKeyValue{StringValue: basetypes.NewStringValue(publicKey)}
+}
+
+func (v sshPublicKeyValue) Equal(o attr.Value) bool {
+	other, ok := o.(sshPublicKeyValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v sshPublicKeyValue) Type(_ context.Context) attr.Type {
+	return sshPublicKeyType{}
+}
+
+func (v sshPublicKeyValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(sshPublicKeyValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return sshPublicKeysEqual(v.ValueString(), newValue.ValueString()), diags
+}
+
+func sshPublicKeysEqual(a, b string) bool {
+	keyA, _, _, _, errA := ssh.ParseAuthorizedKey([]byte(a))
+	keyB, _, _, _, errB := ssh.ParseAuthorizedKey([]byte(b))
+	if errA != nil || errB != nil {
+		return strings.TrimSpace(a) == strings.TrimSpace(b)
+	}
+
+	return bytes.Equal(keyA.Marshal(), keyB.Marshal())
+}
+
+// sshPublicKeyUseStateWhenEqual plans the prior key when the key material is unchanged, semantic equality only
+// applies after apply so an imported key or a key stored by an older version would otherwise be replaced
+type sshPublicKeyUseStateWhenEqual struct{}
+
+func (m sshPublicKeyUseStateWhenEqual) Description(_ context.Context) string {
+	return "Keeps the prior public key when the configured key has the same key material."
+}
+
+func (m sshPublicKeyUseStateWhenEqual) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m sshPublicKeyUseStateWhenEqual) PlanModifyString(_ context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	if sshPublicKeysEqual(req.StateValue.ValueString(), req.PlanValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// maxUserDataSize is the launch API limit for user_data
+const maxUserDataSize = 1 << 20
+
+const (
+	userDataCloudConfigHeader = "#cloud-config"
+	userDataShebang           = "#!"
+)
+
+func userDataAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "The cloud-init user data, either a `#cloud-config` YAML document or a shell script up to 1 MiB, changes force replacement. " +
+			"Requires Terraform 1.11 or later because the value is write-only",
+		Optional:  true,
+		WriteOnly: true,
+	}
+}
+
+func userDataHashAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "The SHA-256 hash of `user_data`, the raw user data is never stored in the state",
+		Computed:            true,
+	}
+}
+
+func validateUserDataAttribute(diags *diag.Diagnostics, userData types.String) {
+	if userData.IsNull() || userData.IsUnknown() {
+		return
+	}
+
+	if err := validateUserData(userData.ValueString()); err != nil {
+		diags.AddAttributeError(
+			path.Root("user_data"),
+			"Invalid Lambdalabs instance user data",
+			err.Error(),
+		)
+	}
+}
+
+func validateUserData(userData string) error {
+	if len(userData) > maxUserDataSize {
+		return fmt.Errorf("user data is %d bytes, exceeding the %d bytes limit", len(userData), maxUserDataSize)
+	}
+
+	switch {
+	case strings.HasPrefix(userData, userDataCloudConfigHeader):
+		var config map[string]any
+		if err := yaml.Unmarshal([]byte(userData), &config); err != nil {
+			return fmt.Errorf("user data is not a valid %s YAML document: %w", userDataCloudConfigHeader, err)
+		}
+	case strings.HasPrefix(userData, userDataShebang):
+		return nil
+	default:
+		return errors.New("user data must start with " + userDataCloudConfigHeader + " or a shell script shebang (" + userDataShebang + ")")
+	}
+
+	return nil
+}
+
+// userDataHash keeps the state free of the raw script which often embeds credentials
+func userDataHash(userData types.String) types.String {
+	if userData.IsUnknown() {
+		return types.StringUnknown()
+	}
+
+	if userData.IsNull() {
+		return types.StringNull()
+	}
+
+	sum := sha256.Sum256([]byte(userData.ValueString()))
+	return types.StringValue(hex.EncodeToString(sum[:]))
+}
+
+// planUserDataHash plans the hash of the configured user data and replaces the resource when it changes,
+// write-only values are absent from the plan so the hash is the only way to detect changes.
+// An imported resource has no hash because the user data cannot be read back, the configured value is trusted instead
+func planUserDataHash(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, stateHash types.String, imported bool) types.String {
+	var userData types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("user_data"), &userData)...)
+	if resp.Diagnostics.HasError() {
+		return types.StringUnknown()
+	}
+
+	hash := userDataHash(userData)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("user_data_hash"), hash)...)
+	if imported && stateHash.IsNull() {
+		return hash
+	}
+
+	if !req.State.Raw.IsNull() && !hash.Equal(stateHash) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("user_data_hash"))
+	}
+
+	return hash
+}
@@ -3,26 +3,35 @@ package lambdalabs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"strings"
 )
 
 const BaseUrl = "https://cloud.lambdalabs.com/api/v1"
 
 type Client struct {
-	baseUrl string
+	baseUrl   string
+	transport *Transport
+	launches  semaphore
 	*http.Client
 }
 
 type ClientOption = func(c *Client)
 
 func New(apiKey string, options ...ClientOption) *Client {
+	transport := &Transport{
+		apiKey: apiKey,
+		retry:  DefaultRetryPolicy,
+	}
+
 	client := &Client{
-		baseUrl: BaseUrl,
+		baseUrl:   BaseUrl,
+		transport: transport,
+		launches:  newSemaphore(DefaultMaxConcurrentLaunches),
 		Client: &http.Client{
-			Transport: &Transport{
-				apiKey: apiKey,
-			},
+			Transport: transport,
 		},
 	}
 
@@ -39,21 +48,68 @@ func WithBaseUrl(baseUrl string) ClientOption {
 	}
 }
 
+// Non-JSON error bodies, e.g. a load balancer HTML page, are truncated to keep diagnostics readable
+const (
+	maxErrorBodySize = 64 * 1024
+	maxRawBodySize   = 512
+	maxDrainBodySize = 64 * 1024
+)
+
 type ErrorResponse struct {
 	Error Error `json:"error"`
 }
 
-func assertError(resp *http.Response) (*http.Response, error) {
-	if resp.StatusCode == http.StatusOK {
+func assertError(resp *http.Response, path string) (*http.Response, error) {
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
 		return resp, nil
 	}
+	defer drainBody(resp)
+
+	apiErr := &Error{}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err == nil {
+		var errorResponse ErrorResponse
+		if json.Unmarshal(body, &errorResponse) == nil {
+			apiErr = &errorResponse.Error
+		} else {
+			apiErr.RawBody = truncate(strings.TrimSpace(string(body)), maxRawBodySize)
+		}
+	}
 
-	var errorResponse ErrorResponse
-	if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err != nil {
-		return nil, err
+	apiErr.StatusCode = resp.StatusCode
+	apiErr.Method = resp.Request.Method
+	apiErr.Path = path
+	return nil, apiErr
+}
+
+// decodeResponse decodes the JSON body into v and releases the connection, an empty body leaves v untouched
+func decodeResponse(resp *http.Response, v any) error {
+	defer drainBody(resp)
+
+	err := json.NewDecoder(resp.Body).Decode(v)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	return err
+}
+
+// drainBody reads the remaining body before closing it to allow the connection to be reused
+func drainBody(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBodySize))
+	_ = resp.Body.Close()
+}
+
+func truncate(s string, size int) string {
+	if len(s) <= size {
+		return s
 	}
 
-	return nil, &errorResponse.Error
+	return s[:size] + "..."
 }
 
 func (c *Client) Get(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
@@ -67,7 +123,7 @@ func (c *Client) Get(ctx context.Context, path string, body io.Reader) (*http.Re
 		return nil, err
 	}
 
-	return assertError(resp)
+	return assertError(resp, path)
 }
 
 func (c *Client) Post(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
@@ -83,7 +139,7 @@ func (c *Client) Post(ctx context.Context, path string, body io.Reader) (*http.R
 		return nil, err
 	}
 
-	return assertError(resp)
+	return assertError(resp, path)
 }
 
 func (c *Client) Delete(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
@@ -97,7 +153,7 @@ func (c *Client) Delete(ctx context.Context, path string, body io.Reader) (*http
 		return nil, err
 	}
 
-	return assertError(resp)
+	return assertError(resp, path)
 }
 
 func (c *Client) Put(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
@@ -113,5 +169,5 @@ func (c *Client) Put(ctx context.Context, path string, body io.Reader) (*http.Re
 		return nil, err
 	}
 
-	return assertError(resp)
+	return assertError(resp, path)
 }
@@ -2,8 +2,10 @@ package lambdalabs_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/elct9620/terraform-provider-lambdalabs/pkg/lambdalabs"
@@ -29,3 +31,104 @@ func TestTransportAddsAuthorizationHeader(t *testing.T) {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 }
+
+func TestGet(t *testing.T) {
+	cases := []struct {
+		name    string
+		status  int
+		body    string
+		err     error
+		kind    error
+		rawBody string
+	}{
+		{
+			name:   "no content",
+			status: http.StatusNoContent,
+			body:   "",
+			err:    nil,
+		},
+		{
+			name:   "accepted",
+			status: http.StatusAccepted,
+			body:   `{"data": {}}`,
+			err:    nil,
+		},
+		{
+			name:   "json error",
+			status: http.StatusNotFound,
+			body:   `{"error": {"code": "global/object-does-not-exist", "message": "Specified instance does not exist."}}`,
+			err: &lambdalabs.Error{
+				Code:       "global/object-does-not-exist",
+				Message:    "Specified instance does not exist.",
+				StatusCode: http.StatusNotFound,
+				Method:     http.MethodGet,
+				Path:       "/test",
+			},
+			kind: lambdalabs.ErrNotFound,
+		},
+		{
+			name:   "html error",
+			status: http.StatusBadGateway,
+			body:   "<html><body>502 Bad Gateway</body></html>\n",
+			err: &lambdalabs.Error{
+				StatusCode: http.StatusBadGateway,
+				Method:     http.MethodGet,
+				Path:       "/test",
+				RawBody:    "<html><body>502 Bad Gateway</body></html>",
+			},
+			kind:    lambdalabs.ErrServerError,
+			rawBody: "<html><body>502 Bad Gateway</body></html>",
+		},
+		{
+			name:   "truncated html error",
+			status: http.StatusBadGateway,
+			body:   strings.Repeat("a", 1024),
+			err: &lambdalabs.Error{
+				StatusCode: http.StatusBadGateway,
+				Method:     http.MethodGet,
+				Path:       "/test",
+				RawBody:    strings.Repeat("a", 512) + "...",
+			},
+			kind:    lambdalabs.ErrServerError,
+			rawBody: strings.Repeat("a", 512) + "...",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(c.status)
+				_, _ = w.Write([]byte(c.body))
+			}))
+			defer server.Close()
+
+			client := lambdalabs.New(
+				"test-key",
+				lambdalabs.WithBaseUrl(server.URL),
+				lambdalabs.WithRetry(lambdalabs.RetryPolicy{MaxAttempts: 1}),
+			)
+			resp, err := client.Get(context.Background(), "/test", nil)
+
+			if c.err == nil {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				_ = resp.Body.Close()
+				return
+			}
+
+			if err == nil || err.Error() != c.err.Error() {
+				t.Errorf("Expected error %v, got %v", c.err, err)
+			}
+
+			if !errors.Is(err, c.kind) {
+				t.Errorf("Expected error to be %v, got %v", c.kind, err)
+			}
+
+			var apiErr *lambdalabs.Error
+			if errors.As(err, &apiErr) && apiErr.RawBody != c.rawBody {
+				t.Errorf("Expected raw body %q, got %q", c.rawBody, apiErr.RawBody)
+			}
+		})
+	}
+}
@@ -1,11 +1,99 @@
 package lambdalabs
 
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	ErrUnknown              = errors.New("lambdalabs: unknown error")
+	ErrInvalidParameters    = errors.New("lambdalabs: invalid parameters")
+	ErrUnauthorized         = errors.New("lambdalabs: unauthorized")
+	ErrAccountInactive      = errors.New("lambdalabs: account inactive")
+	ErrNotFound             = errors.New("lambdalabs: object does not exist")
+	ErrConflict             = errors.New("lambdalabs: object already exists")
+	ErrInUse                = errors.New("lambdalabs: object in use")
+	ErrRateLimited          = errors.New("lambdalabs: rate limited")
+	ErrQuotaExceeded        = errors.New("lambdalabs: quota exceeded")
+	ErrInsufficientCapacity = errors.New("lambdalabs: insufficient capacity")
+	ErrServerError          = errors.New("lambdalabs: server error")
+)
+
+// errorCodes maps the API error codes to sentinel errors, the HTTP status is used when the code is unknown
+var errorCodes = map[string]error{
+	"global/unknown":                                         ErrUnknown,
+	"global/invalid-parameters":                              ErrInvalidParameters,
+	"global/invalid-address":                                 ErrInvalidParameters,
+	"global/invalid-api-key":                                 ErrUnauthorized,
+	"global/account-inactive":                                ErrAccountInactive,
+	"global/object-does-not-exist":                           ErrNotFound,
+	"global/duplicate":                                       ErrConflict,
+	"global/quota-exceeded":                                  ErrQuotaExceeded,
+	"instance-operations/launch/insufficient-capacity":       ErrInsufficientCapacity,
+	"instance-operations/launch/file-system-in-wrong-region": ErrInvalidParameters,
+	"instance-operations/launch/file-systems-not-supported":  ErrInvalidParameters,
+	"filesystems/filesystem-in-use":                          ErrInUse,
+}
+
 type Error struct {
 	Code       string `json:"code"`
 	Message    string `json:"message"`
 	Suggestion string `json:"suggestion"`
+	StatusCode int    `json:"-"`
+	Method     string `json:"-"`
+	Path       string `json:"-"`
+	RawBody    string `json:"-"`
 }
 
 func (e *Error) Error() string {
-	return e.Message
+	message := e.Message
+	if message == "" {
+		message = http.StatusText(e.StatusCode)
+	}
+
+	if e.Message == "" && e.RawBody != "" {
+		message += ": " + e.RawBody
+	}
+
+	if e.Code != "" {
+		message += " (" + e.Code + ")"
+	}
+
+	if e.Method == "" {
+		return message
+	}
+
+	return fmt.Sprintf("%s %s returned HTTP %d: %s", e.Method, e.Path, e.StatusCode, message)
+}
+
+// Is allows errors.Is to match the sentinel errors, e.g. errors.Is(err, ErrNotFound)
+func (e *Error) Is(target error) bool {
+	return e.Kind() == target
+}
+
+// Kind returns the sentinel error which classifies this error
+func (e *Error) Kind() error {
+	if kind, ok := errorCodes[e.Code]; ok {
+		return kind
+	}
+
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return ErrInvalidParameters
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		return ErrAccountInactive
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusConflict:
+		return ErrConflict
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= http.StatusInternalServerError:
+		return ErrServerError
+	}
+
+	return ErrUnknown
 }
@@ -0,0 +1,114 @@
+package lambdalabs_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/elct9620/terraform-provider-lambdalabs/pkg/lambdalabs"
+)
+
+func TestError(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      *lambdalabs.Error
+		expected string
+	}{
+		{
+			name:     "message only",
+			err:      &lambdalabs.Error{Message: "Instance not found"},
+			expected: "Instance not found",
+		},
+		{
+			name: "with request",
+			err: &lambdalabs.Error{
+				Code:       "global/object-does-not-exist",
+				Message:    "Specified instance does not exist.",
+				StatusCode: http.StatusNotFound,
+				Method:     http.MethodGet,
+				Path:       "/instances/inst-123456",
+			},
+			expected: "GET /instances/inst-123456 returned HTTP 404: Specified instance does not exist. (global/object-does-not-exist)",
+		},
+		{
+			name: "without message",
+			err: &lambdalabs.Error{
+				StatusCode: http.StatusBadGateway,
+				Method:     http.MethodGet,
+				Path:       "/instances",
+			},
+			expected: "GET /instances returned HTTP 502: Bad Gateway",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if c.err.Error() != c.expected {
+				t.Errorf("Expected %q, got %q", c.expected, c.err.Error())
+			}
+		})
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{
+			name:     "not found by code",
+			err:      &lambdalabs.Error{Code: "global/object-does-not-exist", StatusCode: http.StatusBadRequest},
+			expected: lambdalabs.ErrNotFound,
+		},
+		{
+			name:     "not found by status",
+			err:      &lambdalabs.Error{StatusCode: http.StatusNotFound},
+			expected: lambdalabs.ErrNotFound,
+		},
+		{
+			name:     "unauthorized",
+			err:      &lambdalabs.Error{Code: "global/invalid-api-key", StatusCode: http.StatusUnauthorized},
+			expected: lambdalabs.ErrUnauthorized,
+		},
+		{
+			name:     "insufficient capacity",
+			err:      &lambdalabs.Error{Code: "instance-operations/launch/insufficient-capacity", StatusCode: http.StatusBadRequest},
+			expected: lambdalabs.ErrInsufficientCapacity,
+		},
+		{
+			name:     "quota exceeded",
+			err:      &lambdalabs.Error{Code: "global/quota-exceeded", StatusCode: http.StatusBadRequest},
+			expected: lambdalabs.ErrQuotaExceeded,
+		},
+		{
+			name:     "rate limited",
+			err:      &lambdalabs.Error{StatusCode: http.StatusTooManyRequests},
+			expected: lambdalabs.ErrRateLimited,
+		},
+		{
+			name:     "file system in use",
+			err:      &lambdalabs.Error{Code: "filesystems/filesystem-in-use", StatusCode: http.StatusBadRequest},
+			expected: lambdalabs.ErrInUse,
+		},
+		{
+			name:     "server error",
+			err:      &lambdalabs.Error{StatusCode: http.StatusServiceUnavailable},
+			expected: lambdalabs.ErrServerError,
+		},
+		{
+			name:     "wrapped",
+			err:      fmt.Errorf("launch: %w", &lambdalabs.Error{StatusCode: http.StatusNotFound}),
+			expected: lambdalabs.ErrNotFound,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if !errors.Is(c.err, c.expected) {
+				t.Errorf("Expected %v to be %v", c.err, c.expected)
+			}
+		})
+	}
+}
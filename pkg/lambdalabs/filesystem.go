@@ -19,7 +19,7 @@ func (c *Client) ListFileSystems(ctx context.Context) (*ListFileSystemsResponse,
 	}
 
 	var res ListFileSystemsResponse
-	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+	if err = decodeResponse(resp, &res); err != nil {
 		return nil, err
 	}
 
@@ -50,7 +50,7 @@ func (c *Client) CreateFileSystem(ctx context.Context, req *CreateFileSystemRequ
 	}
 
 	var res CreateFileSystemResponse
-	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+	if err = decodeResponse(resp, &res); err != nil {
 		return nil, err
 	}
 
@@ -77,7 +77,7 @@ func (c *Client) DeleteFileSystem(ctx context.Context, req *DeleteFileSystemRequ
 	}
 
 	var res DeleteFileSystemResponse
-	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+	if err = decodeResponse(resp, &res); err != nil {
 		return nil, err
 	}
 
@@ -81,7 +81,13 @@ func TestListFileSystems(t *testing.T) {
 				})
 			},
 			expected: nil,
-			err:      &lambdalabs.Error{Message: "API key was invalid, expired, or deleted."},
+			err: &lambdalabs.Error{
+				Code:       "global/invalid-api-key",
+				Message:    "API key was invalid, expired, or deleted.",
+				StatusCode: http.StatusUnauthorized,
+				Method:     http.MethodGet,
+				Path:       "/file-systems",
+			},
 		},
 		{
 			name: "forbidden",
@@ -96,7 +102,13 @@ func TestListFileSystems(t *testing.T) {
 				})
 			},
 			expected: nil,
-			err:      &lambdalabs.Error{Message: "Your account is inactive."},
+			err: &lambdalabs.Error{
+				Code:       "global/account-inactive",
+				Message:    "Your account is inactive.",
+				StatusCode: http.StatusForbidden,
+				Method:     http.MethodGet,
+				Path:       "/file-systems",
+			},
 		},
 	}
 
@@ -174,7 +186,13 @@ func TestDeleteFileSystem(t *testing.T) {
 				})
 			},
 			expected: nil,
-			err:      &lambdalabs.Error{Message: "The filesystem is currently in use by an instance"},
+			err: &lambdalabs.Error{
+				Code:       "filesystems/filesystem-in-use",
+				Message:    "The filesystem is currently in use by an instance",
+				StatusCode: http.StatusBadRequest,
+				Method:     http.MethodDelete,
+				Path:       "/filesystems/398578a2336b49079e74043f0bd2cfe8",
+			},
 		},
 		{
 			name: "unauthorized",
@@ -192,7 +210,13 @@ func TestDeleteFileSystem(t *testing.T) {
 				})
 			},
 			expected: nil,
-			err:      &lambdalabs.Error{Message: "API key was invalid, expired, or deleted."},
+			err: &lambdalabs.Error{
+				Code:       "global/invalid-api-key",
+				Message:    "API key was invalid, expired, or deleted.",
+				StatusCode: http.StatusUnauthorized,
+				Method:     http.MethodDelete,
+				Path:       "/filesystems/398578a2336b49079e74043f0bd2cfe8",
+			},
 		},
 		{
 			name: "forbidden",
@@ -210,7 +234,13 @@ func TestDeleteFileSystem(t *testing.T) {
 				})
 			},
 			expected: nil,
-			err:      &lambdalabs.Error{Message: "Your account is inactive."},
+			err: &lambdalabs.Error{
+				Code:       "global/account-inactive",
+				Message:    "Your account is inactive.",
+				StatusCode: http.StatusForbidden,
+				Method:     http.MethodDelete,
+				Path:       "/filesystems/398578a2336b49079e74043f0bd2cfe8",
+			},
 		},
 		{
 			name: "not found",
@@ -228,7 +258,13 @@ func TestDeleteFileSystem(t *testing.T) {
 				})
 			},
 			expected: nil,
-			err:      &lambdalabs.Error{Message: "Filesystem was not found."},
+			err: &lambdalabs.Error{
+				Code:       "global/object-does-not-exist",
+				Message:    "Filesystem was not found.",
+				StatusCode: http.StatusNotFound,
+				Method:     http.MethodDelete,
+				Path:       "/filesystems/nonexistent-id",
+			},
 		},
 	}
 
@@ -347,7 +383,13 @@ func TestCreateFileSystem(t *testing.T) {
 				})
 			},
 			expected: nil,
-			err:      &lambdalabs.Error{Message: "A file system with this name already exists"},
+			err: &lambdalabs.Error{
+				Code:       "global/duplicate",
+				Message:    "A file system with this name already exists",
+				StatusCode: http.StatusBadRequest,
+				Method:     http.MethodPost,
+				Path:       "/filesystems",
+			},
 		},
 		{
 			name: "unauthorized",
@@ -366,7 +408,13 @@ func TestCreateFileSystem(t *testing.T) {
 				})
 			},
 			expected: nil,
-			err:      &lambdalabs.Error{Message: "API key was invalid, expired, or deleted."},
+			err: &lambdalabs.Error{
+				Code:       "global/invalid-api-key",
+				Message:    "API key was invalid, expired, or deleted.",
+				StatusCode: http.StatusUnauthorized,
+				Method:     http.MethodPost,
+				Path:       "/filesystems",
+			},
 		},
 		{
 			name: "forbidden",
@@ -385,7 +433,13 @@ func TestCreateFileSystem(t *testing.T) {
 				})
 			},
 			expected: nil,
-			err:      &lambdalabs.Error{Message: "Your account is inactive."},
+			err: &lambdalabs.Error{
+				Code:       "global/account-inactive",
+				Message:    "Your account is inactive.",
+				StatusCode: http.StatusForbidden,
+				Method:     http.MethodPost,
+				Path:       "/filesystems",
+			},
 		},
 	}
 
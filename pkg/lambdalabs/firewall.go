@@ -9,7 +9,7 @@ import (
 // FirewallRule represents a firewall rule in the Lambda Labs API
 type FirewallRule struct {
 	Protocol      string `json:"protocol"`
-	PortRange     []int  `json:"port_range"`
+	PortRange     []int  `json:"port_range,omitempty"`
 	SourceNetwork string `json:"source_network"`
 	Description   string `json:"description"`
 }
@@ -27,7 +27,7 @@ func (c *Client) ListFirewallRules(ctx context.Context) (*ListFirewallRulesRespo
 	}
 
 	var res ListFirewallRulesResponse
-	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+	if err = decodeResponse(resp, &res); err != nil {
 		return nil, err
 	}
 
@@ -57,7 +57,7 @@ func (c *Client) ReplaceFirewallRules(ctx context.Context, req *ReplaceFirewallR
 	}
 
 	var res ReplaceFirewallRulesResponse
-	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+	if err = decodeResponse(resp, &res); err != nil {
 		return nil, err
 	}
 
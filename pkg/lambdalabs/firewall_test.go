@@ -2,6 +2,7 @@ package lambdalabs
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -293,3 +294,18 @@ func TestReplaceFirewallRules(t *testing.T) {
 		})
 	}
 }
+
+func TestFirewallRuleWithoutPortRange(t *testing.T) {
+	body, err := json.Marshal(FirewallRule{
+		Protocol:      "icmp",
+		SourceNetwork: "0.0.0.0/0",
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal firewall rule: %v", err)
+	}
+
+	expected := `{"protocol":"icmp","source_network":"0.0.0.0/0","description":""}`
+	if string(body) != expected {
+		t.Errorf("Expected %s, got %s", expected, body)
+	}
+}
@@ -2,7 +2,6 @@ package lambdalabs
 
 import (
 	"context"
-	"encoding/json"
 )
 
 // ListImagesResponse represents the response from the List Images API
@@ -18,7 +17,7 @@ func (c *Client) ListImages(ctx context.Context) (*ListImagesResponse, error) {
 	}
 
 	var res ListImagesResponse
-	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+	if err = decodeResponse(resp, &res); err != nil {
 		return nil, err
 	}
 
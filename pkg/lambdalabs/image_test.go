@@ -74,7 +74,13 @@ func TestListImages(t *testing.T) {
 				})
 			},
 			expected: nil,
-			err:      &lambdalabs.Error{Message: "API key was invalid, expired, or deleted."},
+			err: &lambdalabs.Error{
+				Code:       "global/invalid-api-key",
+				Message:    "API key was invalid, expired, or deleted.",
+				StatusCode: http.StatusUnauthorized,
+				Method:     http.MethodGet,
+				Path:       "/images",
+			},
 		},
 		{
 			name: "forbidden",
@@ -89,7 +95,13 @@ func TestListImages(t *testing.T) {
 				})
 			},
 			expected: nil,
-			err:      &lambdalabs.Error{Message: "Your account is inactive."},
+			err: &lambdalabs.Error{
+				Code:       "global/account-inactive",
+				Message:    "Your account is inactive.",
+				StatusCode: http.StatusForbidden,
+				Method:     http.MethodGet,
+				Path:       "/images",
+			},
 		},
 	}
 
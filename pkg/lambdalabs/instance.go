@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"io"
 )
 
 type RetrieveInstanceRequest struct {
@@ -22,13 +21,56 @@ func (c *Client) RetrieveInstance(ctx context.Context, req *RetrieveInstanceRequ
 		return nil, err
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	var res RetrieveInstanceResponse
+	if err = decodeResponse(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+type UpdateInstanceRequest struct {
+	Id   string `json:"-"`
+	Name string `json:"name"`
+}
+
+type UpdateInstanceResponse struct {
+	Data Instance `json:"data"`
+}
+
+// UpdateInstance updates the mutable details of an instance, e.g. name
+func (c *Client) UpdateInstance(ctx context.Context, req *UpdateInstanceRequest) (*UpdateInstanceResponse, error) {
+	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, err
 	}
 
-	var res RetrieveInstanceResponse
-	if err = json.Unmarshal(body, &res); err != nil {
+	resp, err := c.Post(ctx, "/instances/"+req.Id, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var res UpdateInstanceResponse
+	if err = decodeResponse(resp, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+type ListInstancesResponse struct {
+	Data []Instance `json:"data"`
+}
+
+// ListInstances returns all running instances of the account
+func (c *Client) ListInstances(ctx context.Context) (*ListInstancesResponse, error) {
+	resp, err := c.Get(ctx, "/instances", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var res ListInstancesResponse
+	if err = decodeResponse(resp, &res); err != nil {
 		return nil, err
 	}
 
@@ -47,19 +89,28 @@ func (c *Client) ListInstanceTypes(ctx context.Context) (*ListInstanceTypesRespo
 	}
 
 	var res ListInstanceTypesResponse
-	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+	if err = decodeResponse(resp, &res); err != nil {
 		return nil, err
 	}
 
 	return &res, nil
 }
 
+// LaunchInstanceImage selects the boot image by either ID or family
+type LaunchInstanceImage struct {
+	ID     string `json:"id,omitempty"`
+	Family string `json:"family,omitempty"`
+}
+
 type LaunchInstanceRequest struct {
-	Name             *string  `json:"name,omitempty"`
-	RegionName       string   `json:"region_name"`
-	InstanceTypeName string   `json:"instance_type_name"`
-	SSHKeyNames      []string `json:"ssh_key_names"`
-	FileSystemNames  []string `json:"file_system_names,omitempty"`
+	Name             *string              `json:"name,omitempty"`
+	RegionName       string               `json:"region_name"`
+	InstanceTypeName string               `json:"instance_type_name"`
+	SSHKeyNames      []string             `json:"ssh_key_names"`
+	FileSystemNames  []string             `json:"file_system_names,omitempty"`
+	Image            *LaunchInstanceImage `json:"image,omitempty"`
+	UserData         string               `json:"user_data,omitempty"`
+	Quantity         int                  `json:"quantity,omitempty"`
 }
 
 type LaunchInstanceResponse struct {
@@ -75,13 +126,18 @@ func (c *Client) LaunchInstance(ctx context.Context, req *LaunchInstanceRequest)
 		return nil, err
 	}
 
+	if err := c.launches.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.launches.Release()
+
 	resp, err := c.Post(ctx, "/instance-operations/launch", bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
 
 	var res LaunchInstanceResponse
-	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+	if err = decodeResponse(resp, &res); err != nil {
 		return nil, err
 	}
 
@@ -111,7 +167,7 @@ func (c *Client) TerminateInstance(ctx context.Context, req *TerminateInstanceRe
 	}
 
 	var res TerminateInstanceResponse
-	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+	if err = decodeResponse(resp, &res); err != nil {
 		return nil, err
 	}
 
@@ -25,20 +25,123 @@ func TestRetrieveInstance(t *testing.T) {
 				Id: "inst-123456",
 			},
 			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(map[string]interface{}{ // nolint:errcheck
+					"data": map[string]interface{}{
+						"id":            "inst-123456",
+						"name":          "test-instance",
+						"ip":            "1.2.3.4",
+						"private_ip":    "10.0.0.4",
+						"hostname":      "10-0-0-4",
+						"jupyter_url":   "https://jupyter.example.com",
+						"jupyter_token": "token",
+						"is_reserved":   true,
+						"status":        "active",
+					},
+				})
+			},
+			expected: &lambdalabs.RetrieveInstanceResponse{
+				Data: lambdalabs.Instance{
+					ID:           "inst-123456",
+					Name:         "test-instance",
+					IP:           "1.2.3.4",
+					PrivateIP:    "10.0.0.4",
+					Hostname:     "10-0-0-4",
+					JupyterURL:   "https://jupyter.example.com",
+					JupyterToken: "token",
+					IsReserved:   true,
+					Status:       "active",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "not found",
+			req: &lambdalabs.RetrieveInstanceRequest{
+				Id: "inst-notexist",
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(map[string]interface{}{ // nolint:errcheck
+					"error": map[string]string{
+						"message": "Instance not found",
+					},
+				})
+			},
+			expected: nil,
+			err: &lambdalabs.Error{
+				Message:    "Instance not found",
+				StatusCode: http.StatusNotFound,
+				Method:     http.MethodGet,
+				Path:       "/instances/inst-notexist",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				expectedPath := "/instances/" + c.req.Id
+				if r.URL.Path != expectedPath {
+					t.Errorf("Expected path %q, got %q", expectedPath, r.URL.Path)
+				}
+				if r.Method != http.MethodGet {
+					t.Errorf("Expected method %q, got %q", http.MethodGet, r.Method)
+				}
+
+				c.handler(w, r)
+			}))
+			defer server.Close()
+
+			client := lambdalabs.New("test-key", lambdalabs.WithBaseUrl(server.URL))
+			result, err := client.RetrieveInstance(context.Background(), c.req)
+
+			if !reflect.DeepEqual(c.expected, result) {
+				t.Errorf("Expected %+v, got %+v", c.expected, result)
+			}
+
+			if err != nil && c.err != nil && err.Error() != c.err.Error() {
+				t.Errorf("Expected error %v, got %v", c.err, err)
+			}
+		})
+	}
+}
+
+func TestUpdateInstance(t *testing.T) {
+	cases := []struct {
+		name     string
+		req      *lambdalabs.UpdateInstanceRequest
+		handler  http.HandlerFunc
+		expected *lambdalabs.UpdateInstanceResponse
+		err      error
+	}{
+		{
+			name: "success",
+			req: &lambdalabs.UpdateInstanceRequest{
+				Id:   "inst-123456",
+				Name: "renamed-instance",
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]interface{}
+				json.NewDecoder(r.Body).Decode(&body) // nolint:errcheck
+				if !reflect.DeepEqual(body, map[string]interface{}{"name": "renamed-instance"}) {
+					t.Errorf("Expected body with name only, got %+v", body)
+				}
+
 				w.WriteHeader(http.StatusOK)
 				json.NewEncoder(w).Encode(map[string]interface{}{ // nolint:errcheck
 					"data": map[string]interface{}{
 						"id":     "inst-123456",
-						"name":   "test-instance",
+						"name":   "renamed-instance",
 						"ip":     "1.2.3.4",
 						"status": "active",
 					},
 				})
 			},
-			expected: &lambdalabs.RetrieveInstanceResponse{
+			expected: &lambdalabs.UpdateInstanceResponse{
 				Data: lambdalabs.Instance{
 					ID:     "inst-123456",
-					Name:   "test-instance",
+					Name:   "renamed-instance",
 					IP:     "1.2.3.4",
 					Status: "active",
 				},
@@ -47,8 +150,9 @@ func TestRetrieveInstance(t *testing.T) {
 		},
 		{
 			name: "not found",
-			req: &lambdalabs.RetrieveInstanceRequest{
-				Id: "inst-notexist",
+			req: &lambdalabs.UpdateInstanceRequest{
+				Id:   "inst-notexist",
+				Name: "renamed-instance",
 			},
 			handler: func(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusNotFound)
@@ -59,7 +163,12 @@ func TestRetrieveInstance(t *testing.T) {
 				})
 			},
 			expected: nil,
-			err:      &lambdalabs.Error{Message: "Instance not found"},
+			err: &lambdalabs.Error{
+				Message:    "Instance not found",
+				StatusCode: http.StatusNotFound,
+				Method:     http.MethodPost,
+				Path:       "/instances/inst-notexist",
+			},
 		},
 	}
 
@@ -70,6 +179,106 @@ func TestRetrieveInstance(t *testing.T) {
 				if r.URL.Path != expectedPath {
 					t.Errorf("Expected path %q, got %q", expectedPath, r.URL.Path)
 				}
+				if r.Method != http.MethodPost {
+					t.Errorf("Expected method %q, got %q", http.MethodPost, r.Method)
+				}
+
+				c.handler(w, r)
+			}))
+			defer server.Close()
+
+			client := lambdalabs.New("test-key", lambdalabs.WithBaseUrl(server.URL))
+			result, err := client.UpdateInstance(context.Background(), c.req)
+
+			if !reflect.DeepEqual(c.expected, result) {
+				t.Errorf("Expected %+v, got %+v", c.expected, result)
+			}
+
+			if err != nil && c.err != nil && err.Error() != c.err.Error() {
+				t.Errorf("Expected error %v, got %v", c.err, err)
+			}
+		})
+	}
+}
+
+func TestListInstances(t *testing.T) {
+	cases := []struct {
+		name     string
+		handler  http.HandlerFunc
+		expected *lambdalabs.ListInstancesResponse
+		err      error
+	}{
+		{
+			name: "success",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(map[string]interface{}{ // nolint:errcheck
+					"data": []map[string]interface{}{
+						{
+							"id":                "inst-123456",
+							"name":              "training-node-1",
+							"ip":                "1.2.3.4",
+							"status":            "active",
+							"ssh_key_names":     []string{"my-key"},
+							"file_system_names": []string{"shared-fs"},
+							"region": map[string]interface{}{
+								"name":        "us-west-1",
+								"description": "California, USA",
+							},
+							"instance_type": map[string]interface{}{
+								"name": "gpu_1x_a100",
+							},
+						},
+					},
+				})
+			},
+			expected: &lambdalabs.ListInstancesResponse{
+				Data: []lambdalabs.Instance{
+					{
+						ID:              "inst-123456",
+						Name:            "training-node-1",
+						IP:              "1.2.3.4",
+						Status:          "active",
+						SSHKeyNames:     []string{"my-key"},
+						FileSystemNames: []string{"shared-fs"},
+						Region: lambdalabs.Region{
+							Name:        "us-west-1",
+							Description: "California, USA",
+						},
+						InstanceType: lambdalabs.InstanceType{
+							Name: "gpu_1x_a100",
+						},
+					},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "unauthorized",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(map[string]interface{}{ // nolint:errcheck
+					"error": map[string]string{
+						"message": "Unauthorized access",
+					},
+				})
+			},
+			expected: nil,
+			err: &lambdalabs.Error{
+				Message:    "Unauthorized access",
+				StatusCode: http.StatusUnauthorized,
+				Method:     http.MethodGet,
+				Path:       "/instances",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/instances" {
+					t.Errorf("Expected path %q, got %q", "/instances", r.URL.Path)
+				}
 				if r.Method != http.MethodGet {
 					t.Errorf("Expected method %q, got %q", http.MethodGet, r.Method)
 				}
@@ -79,7 +288,7 @@ func TestRetrieveInstance(t *testing.T) {
 			defer server.Close()
 
 			client := lambdalabs.New("test-key", lambdalabs.WithBaseUrl(server.URL))
-			result, err := client.RetrieveInstance(context.Background(), c.req)
+			result, err := client.ListInstances(context.Background())
 
 			if !reflect.DeepEqual(c.expected, result) {
 				t.Errorf("Expected %+v, got %+v", c.expected, result)
@@ -124,6 +333,114 @@ func TestLaunchInstance(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "with image",
+			req: &lambdalabs.LaunchInstanceRequest{
+				RegionName:       "us-east-1",
+				InstanceTypeName: "gpu-1x-a100",
+				SSHKeyNames:      []string{"my-key"},
+				Image: &lambdalabs.LaunchInstanceImage{
+					Family: "lambda-stack-22-04",
+				},
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]interface{}
+				json.NewDecoder(r.Body).Decode(&body) // nolint:errcheck
+				if !reflect.DeepEqual(body["image"], map[string]interface{}{"family": "lambda-stack-22-04"}) {
+					t.Errorf("Expected image family only, got %+v", body["image"])
+				}
+
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(map[string]interface{}{ // nolint:errcheck
+					"data": map[string]interface{}{
+						"instance_ids": []string{"inst-123456"},
+					},
+				})
+			},
+			expected: &lambdalabs.LaunchInstanceResponse{
+				Data: struct {
+					IDs []string `json:"instance_ids"`
+				}{
+					IDs: []string{"inst-123456"},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "with user data",
+			req: &lambdalabs.LaunchInstanceRequest{
+				RegionName:       "us-east-1",
+				InstanceTypeName: "gpu-1x-a100",
+				SSHKeyNames:      []string{"my-key"},
+				UserData:         "#cloud-config\npackages:\n  - htop\n",
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]interface{}
+				json.NewDecoder(r.Body).Decode(&body) // nolint:errcheck
+				if body["user_data"] != "#cloud-config\npackages:\n  - htop\n" {
+					t.Errorf("Expected user data to be sent, got %+v", body["user_data"])
+				}
+
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(map[string]interface{}{ // nolint:errcheck
+					"data": map[string]interface{}{
+						"instance_ids": []string{"inst-123456"},
+					},
+				})
+			},
+			expected: &lambdalabs.LaunchInstanceResponse{
+				Data: struct {
+					IDs []string `json:"instance_ids"`
+				}{
+					IDs: []string{"inst-123456"},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "with quantity",
+			req: &lambdalabs.LaunchInstanceRequest{
+				RegionName:       "us-east-1",
+				InstanceTypeName: "gpu-1x-a100",
+				SSHKeyNames:      []string{"my-key"},
+				Quantity:         2,
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]interface{}
+				json.NewDecoder(r.Body).Decode(&body) // nolint:errcheck
+				if body["quantity"] != float64(2) {
+					t.Errorf("Expected quantity to be sent, got %+v", body["quantity"])
+				}
+
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(map[string]interface{}{ // nolint:errcheck
+					"data": map[string]interface{}{
+						"instance_ids": []string{"inst-123456", "inst-123457"},
+					},
+				})
+			},
+			expected: &lambdalabs.LaunchInstanceResponse{
+				Data: struct {
+					IDs []string `json:"instance_ids"`
+				}{
+					IDs: []string{"inst-123456", "inst-123457"},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "empty response",
+			req: &lambdalabs.LaunchInstanceRequest{
+				RegionName:       "us-east-1",
+				InstanceTypeName: "gpu-1x-a100",
+				SSHKeyNames:      []string{"my-key"},
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+			},
+			expected: &lambdalabs.LaunchInstanceResponse{},
+			err:      nil,
+		},
 		{
 			name: "invalid instance type",
 			req: &lambdalabs.LaunchInstanceRequest{
@@ -140,7 +457,12 @@ func TestLaunchInstance(t *testing.T) {
 				})
 			},
 			expected: nil,
-			err:      &lambdalabs.Error{Message: "Invalid instance type"},
+			err: &lambdalabs.Error{
+				Message:    "Invalid instance type",
+				StatusCode: http.StatusBadRequest,
+				Method:     http.MethodPost,
+				Path:       "/instance-operations/launch",
+			},
 		},
 	}
 
@@ -245,7 +567,12 @@ func TestListInstanceTypes(t *testing.T) {
 				})
 			},
 			expected: nil,
-			err:      &lambdalabs.Error{Message: "Unauthorized access"},
+			err: &lambdalabs.Error{
+				Message:    "Unauthorized access",
+				StatusCode: http.StatusUnauthorized,
+				Method:     http.MethodGet,
+				Path:       "/instance-types",
+			},
 		},
 	}
 
@@ -331,7 +658,12 @@ func TestTerminateInstance(t *testing.T) {
 				})
 			},
 			expected: nil,
-			err:      &lambdalabs.Error{Message: "Instance not found"},
+			err: &lambdalabs.Error{
+				Message:    "Instance not found",
+				StatusCode: http.StatusNotFound,
+				Method:     http.MethodPost,
+				Path:       "/instance-operations/terminate",
+			},
 		},
 	}
 
@@ -0,0 +1,109 @@
+package lambdalabs
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// DefaultMaxConcurrentLaunches serializes launches because the launch endpoint is throttled far more aggressively than others
+const DefaultMaxConcurrentLaunches = 1
+
+// WithRequestsPerSecond limits the request rate shared by every caller of the client, zero disables the limit
+func WithRequestsPerSecond(rps float64) ClientOption {
+	return func(c *Client) {
+		c.transport.limiter = newRateLimiter(rps)
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of in-flight requests, zero disables the limit
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(c *Client) {
+		c.transport.inflight = newSemaphore(n)
+	}
+}
+
+// WithMaxConcurrentLaunches limits the number of in-flight LaunchInstance calls, zero disables the limit
+func WithMaxConcurrentLaunches(n int) ClientOption {
+	return func(c *Client) {
+		c.launches = newSemaphore(n)
+	}
+}
+
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rps float64) *rateLimiter {
+	if rps <= 0 {
+		return nil
+	}
+
+	burst := math.Max(1, math.Ceil(rps))
+	return &rateLimiter{
+		rate:   rps,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	// Reserve the token up front so concurrent callers queue behind each other instead of waking together
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+
+	return sleepContext(ctx, wait)
+}
+
+type semaphore chan struct{}
+
+func newSemaphore(n int) semaphore {
+	if n <= 0 {
+		return nil
+	}
+
+	return make(semaphore, n)
+}
+
+func (s semaphore) Acquire(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s semaphore) Release() {
+	if s == nil {
+		return
+	}
+
+	<-s
+}
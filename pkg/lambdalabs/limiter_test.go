@@ -0,0 +1,134 @@
+package lambdalabs_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/elct9620/terraform-provider-lambdalabs/pkg/lambdalabs"
+)
+
+func TestWithRequestsPerSecond(t *testing.T) {
+	cases := []struct {
+		name     string
+		rps      float64
+		requests int
+		minimum  time.Duration
+	}{
+		{
+			name:     "within burst",
+			rps:      10,
+			requests: 10,
+			minimum:  0,
+		},
+		{
+			name:     "exceed burst",
+			rps:      10,
+			requests: 13,
+			minimum:  250 * time.Millisecond,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := lambdalabs.New("test-key", lambdalabs.WithBaseUrl(server.URL), lambdalabs.WithRequestsPerSecond(c.rps))
+
+			start := time.Now()
+			for i := 0; i < c.requests; i++ {
+				if _, err := client.Get(context.Background(), "/test", nil); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+
+			if elapsed := time.Since(start); elapsed < c.minimum {
+				t.Errorf("Expected at least %v, got %v", c.minimum, elapsed)
+			}
+		})
+	}
+}
+
+func TestWithMaxConcurrentRequests(t *testing.T) {
+	cases := []struct {
+		name     string
+		options  []lambdalabs.ClientOption
+		call     func(*lambdalabs.Client) error
+		expected int32
+	}{
+		{
+			name:    "limit requests",
+			options: []lambdalabs.ClientOption{lambdalabs.WithMaxConcurrentRequests(2)},
+			call: func(c *lambdalabs.Client) error {
+				_, err := c.Get(context.Background(), "/test", nil)
+				return err
+			},
+			expected: 2,
+		},
+		{
+			name: "limit launches by default",
+			call: func(c *lambdalabs.Client) error {
+				_, err := c.LaunchInstance(context.Background(), &lambdalabs.LaunchInstanceRequest{})
+				return err
+			},
+			expected: lambdalabs.DefaultMaxConcurrentLaunches,
+		},
+		{
+			name:    "limit launches",
+			options: []lambdalabs.ClientOption{lambdalabs.WithMaxConcurrentLaunches(3)},
+			call: func(c *lambdalabs.Client) error {
+				_, err := c.LaunchInstance(context.Background(), &lambdalabs.LaunchInstanceRequest{})
+				return err
+			},
+			expected: 3,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var inflight, peak atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				current := inflight.Add(1)
+				defer inflight.Add(-1)
+
+				for {
+					observed := peak.Load()
+					if current <= observed || peak.CompareAndSwap(observed, current) {
+						break
+					}
+				}
+
+				time.Sleep(50 * time.Millisecond)
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"data":{"instance_ids":[]}}`))
+			}))
+			defer server.Close()
+
+			options := append([]lambdalabs.ClientOption{lambdalabs.WithBaseUrl(server.URL)}, c.options...)
+			client := lambdalabs.New("test-key", options...)
+
+			var wg sync.WaitGroup
+			for i := 0; i < 6; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if err := c.call(client); err != nil {
+						t.Errorf("Unexpected error: %v", err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if peak.Load() != c.expected {
+				t.Errorf("Expected %d concurrent requests, got %d", c.expected, peak.Load())
+			}
+		})
+	}
+}
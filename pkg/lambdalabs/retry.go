@@ -0,0 +1,137 @@
+package lambdalabs
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const RetryAfterHeader = "Retry-After"
+
+// RetryPolicy describes how failed requests are replayed
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one, a value less than 2 disables retry
+	MaxAttempts int
+	// MinWait is the backoff before the second attempt, zero retries immediately
+	MinWait time.Duration
+	// MaxWait caps the exponential backoff between attempts, a longer Retry-After is returned without retrying
+	MaxWait time.Duration
+	// Methods lists the HTTP methods which are safe to replay
+	Methods []string
+}
+
+// DefaultRetryPolicy only replays idempotent requests
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinWait:     1 * time.Second,
+	MaxWait:     30 * time.Second,
+	Methods: []string{
+		http.MethodGet,
+		http.MethodPut,
+		http.MethodDelete,
+	},
+}
+
+// WithRetry replaces the retry policy used by the client transport
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		if policy.Methods == nil {
+			policy.Methods = DefaultRetryPolicy.Methods
+		}
+
+		c.transport.retry = policy
+	}
+}
+
+func (p *RetryPolicy) allows(req *http.Request) bool {
+	if p.MaxAttempts < 2 {
+		return false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	return slices.Contains(p.Methods, req.Method)
+}
+
+func (p *RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// backoff returns false when the server asks to wait longer than MaxWait, the response is returned as is
+// instead of stalling the caller until the context is cancelled
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get(RetryAfterHeader)); ok {
+			return wait, wait <= p.MaxWait
+		}
+	}
+
+	// A zero MinWait retries immediately instead of falling back to MaxWait
+	if p.MinWait <= 0 {
+		return 0, true
+	}
+
+	wait := p.MaxWait
+	if shift := attempt - 1; shift < 32 {
+		if exp := p.MinWait << shift; exp > 0 && exp < p.MaxWait {
+			wait = exp
+		}
+	}
+
+	if wait <= 0 {
+		return 0, true
+	}
+
+	// Jitter spreads out parallel resources retrying against the same throttled endpoint
+	half := wait / 2
+	return half + rand.N(wait-half+1), true
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,178 @@
+package lambdalabs_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/elct9620/terraform-provider-lambdalabs/pkg/lambdalabs"
+)
+
+func TestWithRetry(t *testing.T) {
+	cases := []struct {
+		name     string
+		method   string
+		body     string
+		statuses []int
+		header   http.Header
+		attempts int
+		err      bool
+	}{
+		{
+			name:     "retry bad gateway",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusBadGateway, http.StatusOK},
+			attempts: 2,
+			err:      false,
+		},
+		{
+			name:     "retry too many requests with retry after",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			header:   http.Header{lambdalabs.RetryAfterHeader: []string{"0"}},
+			attempts: 2,
+			err:      false,
+		},
+		{
+			name:     "do not wait longer than max wait",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			header:   http.Header{lambdalabs.RetryAfterHeader: []string{"3600"}},
+			attempts: 1,
+			err:      true,
+		},
+		{
+			name:     "do not wait until a distant date",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			header:   http.Header{lambdalabs.RetryAfterHeader: []string{"Fri, 31 Dec 2100 23:59:59 GMT"}},
+			attempts: 1,
+			err:      true,
+		},
+		{
+			name:     "replay body on put",
+			method:   http.MethodPut,
+			body:     `{"data":[]}`,
+			statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			attempts: 2,
+			err:      false,
+		},
+		{
+			name:     "give up after max attempts",
+			method:   http.MethodDelete,
+			statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			attempts: 3,
+			err:      true,
+		},
+		{
+			name:     "do not replay post",
+			method:   http.MethodPost,
+			body:     `{}`,
+			statuses: []int{http.StatusBadGateway, http.StatusOK},
+			attempts: 1,
+			err:      true,
+		},
+		{
+			name:     "do not retry bad request",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusBadRequest, http.StatusOK},
+			attempts: 1,
+			err:      true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != c.body {
+					t.Errorf("Expected body %q, got %q", c.body, string(body))
+				}
+
+				status := c.statuses[min(attempts, len(c.statuses)-1)]
+				attempts++
+
+				for key, values := range c.header {
+					w.Header()[key] = values
+				}
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(`{"data":{}}`))
+			}))
+			defer server.Close()
+
+			client := lambdalabs.New(
+				"test-key",
+				lambdalabs.WithBaseUrl(server.URL),
+				lambdalabs.WithRetry(lambdalabs.RetryPolicy{
+					MaxAttempts: 3,
+					MinWait:     time.Millisecond,
+					MaxWait:     10 * time.Millisecond,
+				}),
+			)
+
+			var err error
+			switch c.method {
+			case http.MethodGet:
+				_, err = client.Get(context.Background(), "/test", nil)
+			case http.MethodPost:
+				_, err = client.Post(context.Background(), "/test", bytes.NewBufferString(c.body))
+			case http.MethodPut:
+				_, err = client.Put(context.Background(), "/test", bytes.NewBufferString(c.body))
+			case http.MethodDelete:
+				_, err = client.Delete(context.Background(), "/test", nil)
+			}
+
+			if attempts != c.attempts {
+				t.Errorf("Expected %d attempts, got %d", c.attempts, attempts)
+			}
+
+			if c.err && err == nil {
+				t.Errorf("Expected error, got nil")
+			}
+
+			if !c.err && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWithRetry_ZeroMinWait(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	client := lambdalabs.New(
+		"test-key",
+		lambdalabs.WithBaseUrl(server.URL),
+		lambdalabs.WithRetry(lambdalabs.RetryPolicy{
+			MaxAttempts: 3,
+			MinWait:     0,
+			MaxWait:     time.Hour,
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := client.Get(ctx, "/test", nil); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if attempts.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts.Load())
+	}
+}
@@ -4,6 +4,11 @@ type Instance struct {
 	ID              string       `json:"id"`
 	Name            string       `json:"name"`
 	IP              string       `json:"ip"`
+	PrivateIP       string       `json:"private_ip"`
+	Hostname        string       `json:"hostname"`
+	JupyterURL      string       `json:"jupyter_url"`
+	JupyterToken    string       `json:"jupyter_token"`
+	IsReserved      bool         `json:"is_reserved"`
 	Status          string       `json:"status"`
 	SSHKeyNames     []string     `json:"ssh_key_names"`
 	FileSystemNames []string     `json:"file_system_names,omitempty"`
@@ -24,7 +24,7 @@ func (c *Client) ListSshKeys(ctx context.Context) (*ListSshKeysResponse, error)
 	}
 
 	var res ListSshKeysResponse
-	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+	if err = decodeResponse(resp, &res); err != nil {
 		return nil, err
 	}
 
@@ -52,7 +52,7 @@ func (c *Client) CreateSshKey(ctx context.Context, req *CreateSshKeyRequest) (*C
 	}
 
 	var res CreateSshKeyResponse
-	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+	if err = decodeResponse(resp, &res); err != nil {
 		return nil, err
 	}
 
@@ -64,10 +64,11 @@ type DeleteSshKeyRequest struct {
 }
 
 func (c *Client) DeleteSshKey(ctx context.Context, req *DeleteSshKeyRequest) error {
-	_, err := c.Delete(ctx, "/ssh-keys/"+req.Id, nil)
+	resp, err := c.Delete(ctx, "/ssh-keys/"+req.Id, nil)
 	if err != nil {
 		return err
 	}
+	drainBody(resp)
 
 	return nil
 }
@@ -3,6 +3,7 @@ package lambdalabs_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -56,7 +57,13 @@ func TestListSshKeys(t *testing.T) {
 				})
 			},
 			expected: nil,
-			err:      &lambdalabs.Error{Message: "API key was invalid, expired, or deleted."},
+			err: &lambdalabs.Error{
+				Code:       "global/invalid-api-key",
+				Message:    "API key was invalid, expired, or deleted.",
+				StatusCode: http.StatusUnauthorized,
+				Method:     http.MethodGet,
+				Path:       "/ssh-keys",
+			},
 		},
 	}
 
@@ -254,7 +261,8 @@ func TestDeleteSshKey(t *testing.T) {
 		if err == nil {
 			t.Fatal("Expected error for non-existent SSH key")
 		}
-		if err.Error() != "SSH key not found" {
+		var apiErr *lambdalabs.Error
+		if !errors.As(err, &apiErr) || apiErr.Message != "SSH key not found" {
 			t.Errorf("Expected error %v, got %v", "SSH key not found", err)
 		}
 	})
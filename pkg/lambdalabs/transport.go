@@ -8,11 +8,63 @@ const (
 )
 
 type Transport struct {
-	apiKey string
+	apiKey   string
+	retry    RetryPolicy
+	limiter  *rateLimiter
+	inflight semaphore
 }
 
 func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
-	req.Header.Add(AuthorizationHeader, AuthorizationType+" "+t.apiKey)
+	if !t.retry.allows(req) {
+		return t.send(t.authorize(req))
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		attemptReq := t.authorize(req)
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+
+		resp, err := t.send(attemptReq)
+		if attempt >= t.retry.MaxAttempts || !t.retry.shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		wait, ok := t.retry.backoff(attempt, resp)
+		if !ok {
+			return resp, err
+		}
+		drainBody(resp)
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// authorize clones the request because a RoundTripper must not modify the caller's request
+func (t *Transport) authorize(req *http.Request) *http.Request {
+	authorized := req.Clone(req.Context())
+	authorized.Header.Set(AuthorizationHeader, AuthorizationType+" "+t.apiKey)
+
+	return authorized
+}
+
+func (t *Transport) send(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if err := t.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := t.inflight.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer t.inflight.Release()
 
 	return http.DefaultTransport.RoundTrip(req)
 }